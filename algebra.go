@@ -0,0 +1,257 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+// limit is the position of a boundary between Versions.
+//
+// Boundaries behave like LimitedEqual and limitedLess do,
+// and therefore consider only the fields up to the specifier, and any pre-release identifiers.
+type limit struct {
+	at    Version
+	after bool // Right after all Versions that share 'at', else right before them.
+}
+
+// Sentinels for the release type, to place a boundary before any
+// pre-release or after any patch-level of a prefix.
+const (
+	releaseTypeBelowAll = -1 << 31
+	releaseTypeAboveAll = 1<<31 - 1
+)
+
+func newLimit(v Version, after bool) limit {
+	l := limit{after: after}
+	copy(l.at.version[:idxSpecifierType], v.version[:idxSpecifierType])
+	l.at.preRelease = v.preRelease
+	return l
+}
+
+// newPrefixLimit returns a limit before all pre-releases,
+// or after all patch-levels of the Version's prefix.
+func newPrefixLimit(v Version, after bool) limit {
+	l := limit{after: after}
+	copy(l.at.version[:idxReleaseType], v.version[:idxReleaseType])
+	if after {
+		l.at.version[idxReleaseType] = releaseTypeAboveAll
+	} else {
+		l.at.version[idxReleaseType] = releaseTypeBelowAll
+	}
+	return l
+}
+
+// isPrefixLimit is true for limits by newPrefixLimit.
+func (l limit) isPrefixLimit() bool {
+	typ := l.at.version[idxReleaseType]
+	return typ == releaseTypeBelowAll || typ == releaseTypeAboveAll
+}
+
+// compare returns the signum of the difference between two limits.
+func (l limit) compare(o limit) int {
+	var c int
+	switch {
+	case l.isPrefixLimit() || o.isPrefixLimit():
+		for i := 0; i <= idxReleaseType && c == 0; i++ { // No signDelta, which the sentinels would overflow.
+			if l.at.version[i] != o.at.version[i] {
+				c = 1
+				if l.at.version[i] < o.at.version[i] {
+					c = -1
+				}
+			}
+		}
+	case l.at.preRelease != "" || o.at.preRelease != "":
+		c = compareWithIdentifiers(&l.at, &o.at)
+	default:
+		c = int(signDelta(l.at.version, o.at.version, idxSpecifierType))
+	}
+	switch {
+	case c != 0:
+		return c
+	case l.after == o.after:
+		return 0
+	case o.after:
+		return -1
+	}
+	return 1
+}
+
+// isNextTo is true if there's no Version between l, which is after, and o, which is before.
+//
+// Between pre-releases with identifiers there is assumed to be room.
+func (l limit) isNextTo(o limit) bool {
+	if !l.after || o.after || l.at.preRelease != "" || o.at.preRelease != "" {
+		return false
+	}
+	last := idxSpecifierType - 1
+	for i := 0; i < last; i++ {
+		if l.at.version[i] != o.at.version[i] {
+			return false
+		}
+	}
+	return l.at.version[last]+1 == o.at.version[last]
+}
+
+// lowerLimit is where the lower boundary is, which must exist.
+func (r Range) lowerLimit() limit {
+	switch {
+	case r.equalsLower:
+		return newLimit(r.lower, false)
+	case r.lower.version[idxReleaseType] == common: // Excludes its patch-levels, see LimitedEqual.
+		return newPrefixLimit(r.lower, true)
+	}
+	return newLimit(r.lower, true)
+}
+
+// upperLimit is where the upper boundary is, which must exist.
+func (r Range) upperLimit() limit {
+	switch {
+	case r.upper.version[idxReleaseType] != common:
+		return newLimit(r.upper, r.equalsUpper)
+	case r.equalsUpper: // Includes its patch-levels.
+		return newPrefixLimit(r.upper, true)
+	case r.admitsPreReleasesOfUpper():
+		return newLimit(r.upper, false)
+	}
+	return newPrefixLimit(r.upper, false) // Excludes its pre-releases.
+}
+
+// compareLower returns the signum of the difference between the lower boundaries.
+// A missing boundary is the lowest.
+func (r Range) compareLower(o Range) int {
+	switch {
+	case !r.hasLower && !o.hasLower:
+		return 0
+	case !r.hasLower:
+		return -1
+	case !o.hasLower:
+		return 1
+	}
+	return r.lowerLimit().compare(o.lowerLimit())
+}
+
+// compareUpper returns the signum of the difference between the upper boundaries.
+// A missing boundary is the highest.
+func (r Range) compareUpper(o Range) int {
+	switch {
+	case !r.hasUpper && !o.hasUpper:
+		return 0
+	case !r.hasUpper:
+		return 1
+	case !o.hasUpper:
+		return -1
+	}
+	return r.upperLimit().compare(o.upperLimit())
+}
+
+// endsBefore is true if r's upper boundary is below o's lower boundary,
+// leaving room for Versions in between.
+func (r Range) endsBefore(o Range) bool {
+	if !r.hasUpper || !o.hasLower {
+		return false
+	}
+	upper, lower := r.upperLimit(), o.lowerLimit()
+	return upper.compare(lower) < 0
+}
+
+// IsEmpty is true if no Version can be inside this Range,
+// such as in ">=1.2.3 <1.2.3" or ">2.0 <1.0".
+func (r Range) IsEmpty() bool {
+	if !r.hasLower || !r.hasUpper {
+		return false
+	}
+	lower, upper := r.lowerLimit(), r.upperLimit()
+	return lower.compare(upper) >= 0 || lower.isNextTo(upper)
+}
+
+// Intersect returns the Range of Versions that are in both Ranges.
+// The second return value is false if they don't overlap,
+// in which case the returned Range is empty.
+//
+// The tighter boundaries are taken unchanged, and with them
+// which pre-releases IsSatisfiedBy will accept.
+func (r Range) Intersect(o Range) (Range, bool) {
+	result, upperFrom := r, r
+	if r.compareLower(o) < 0 {
+		result.lower, result.hasLower, result.equalsLower = o.lower, o.hasLower, o.equalsLower
+	}
+	if r.compareUpper(o) > 0 {
+		result.upper, result.hasUpper, result.equalsUpper = o.upper, o.hasUpper, o.equalsUpper
+		upperFrom = o
+	}
+	if result.hasUpper && result.upperLimit().compare(upperFrom.upperLimit()) > 0 {
+		// A pre-release lower boundary would admit the pre-releases that "<1.2.0" excludes,
+		// yet it is above them all: as in ">=1.2.0-rc1" and "<1.2.0" nothing is in both.
+		result.upper, result.equalsUpper = result.lower, false
+	}
+	return result, !result.IsEmpty()
+}
+
+// Union returns a RangeSet that contains the Versions of either Range.
+//
+// Overlapping or adjoining Ranges are merged into one,
+// else the RangeSet will have both, the lower one first.
+func (r Range) Union(o Range) RangeSet {
+	switch {
+	case o.IsEmpty():
+		return RangeSet{r}
+	case r.IsEmpty():
+		return RangeSet{o}
+	}
+	if r.compareLower(o) > 0 {
+		r, o = o, r
+	}
+	if r.endsBefore(o) && !r.upperLimit().isNextTo(o.lowerLimit()) {
+		return RangeSet{r, o}
+	}
+
+	if r.compareUpper(o) < 0 {
+		r.upper, r.hasUpper, r.equalsUpper = o.upper, o.hasUpper, o.equalsUpper
+	}
+	return RangeSet{r}
+}
+
+// Complement returns all Versions not in this Range,
+// which can take up to three Ranges.
+//
+// Boundaries flip inclusiveness. The pre-releases of a lower boundary such as 1.2.3 in ">=1.2.3",
+// which "<1.2.3" excludes as well, get a Range of their own: ">=1.2.3-0 <1.2.3".
+// The complement of "<1.2.3" is ">=1.2.3-0", which starts at its lowest pre-release.
+func (r Range) Complement() RangeSet {
+	if r.IsEmpty() {
+		return RangeSet{Range{}}
+	}
+	set := make(RangeSet, 0, 3)
+	if r.hasLower {
+		set = append(set, Range{upper: r.lower, hasUpper: true, equalsUpper: !r.equalsLower})
+		if r.equalsLower && r.lower.version[idxReleaseType] == common {
+			set = append(set, Range{lower: lowestPreRelease(r.lower), hasLower: true, equalsLower: true,
+				upper: r.lower, hasUpper: true})
+		}
+	}
+	if r.hasUpper {
+		switch {
+		case r.equalsUpper || r.upper.version[idxReleaseType] != common || r.admitsPreReleasesOfUpper():
+			set = append(set, Range{lower: r.upper, hasLower: true, equalsLower: !r.equalsUpper})
+		default:
+			set = append(set, Range{lower: lowestPreRelease(r.upper), hasLower: true, equalsLower: true})
+		}
+	}
+	return set
+}
+
+// lowestPreRelease returns the pre-release "-0" of the Version's prefix, which is below any other.
+func lowestPreRelease(v Version) Version {
+	l := Version{}
+	copy(l.version[:idxReleaseType], v.version[:idxReleaseType])
+	l.version[idxReleaseType], l.preRelease = identified, "0"
+	return l
+}
+
+// IsSubsetOf is true if every Version in this Range is in the other, too.
+// An empty Range is a subset of any Range.
+func (r Range) IsSubsetOf(o Range) bool {
+	if r.IsEmpty() {
+		return true
+	}
+	return r.compareLower(o) >= 0 && r.compareUpper(o) <= 0
+}
@@ -0,0 +1,205 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustRange(str string) Range {
+	r, err := NewRange([]byte(str))
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
+
+// mustVersion reads a Version as NewRange does its boundaries.
+func mustVersion(str string) Version {
+	v, err := newVocabularyVersion([]byte(str))
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestRangeIsEmpty(t *testing.T) {
+	Convey("Empty are Ranges…", t, FailureContinues, func() {
+		for _, str := range []string{
+			">=1.2.3 <1.2.3", ">1.2.3 <=1.2.3", ">2.0 <1.0",
+			">1.2.3 <1.2.3-p1", ">1.2.3-rc <1.2.3-rc", ">=1.2.3-alpha.y <1.2.3-alpha.x",
+			">1.2.3-alpha0.0.0.0 <1.2.3-alpha0.0.0.1",
+		} {
+			Convey(str, func() {
+				So(mustRange(str).IsEmpty(), ShouldBeTrue)
+			})
+		}
+	})
+
+	Convey("Not empty are Ranges…", t, FailureContinues, func() {
+		for _, str := range []string{
+			"", "1.2.3", ">=1.2.3 <=1.2.3", ">1.2.3 <1.2.4", ">=1.2.3-rc <=1.2.3",
+			">=1.2.3-rc <1.2.3", ">1.2.3-rc <1.2.3", ">=1.2.3-alpha.x <1.2.3-alpha.y",
+			"<1.0", ">1.0", ">1.2.3-alpha0.0.0.0 <1.2.3-alpha0.0.0.2",
+		} {
+			Convey(str, func() {
+				So(mustRange(str).IsEmpty(), ShouldBeFalse)
+			})
+		}
+	})
+}
+
+func TestRangeIntersect(t *testing.T) {
+	Convey("Intersecting…", t, func() {
+		Convey("^1.2 and <1.5 yields >=1.2.0 <1.5.0", func() {
+			r, ok := mustRange("^1.2").Intersect(mustRange("<1.5"))
+			So(ok, ShouldBeTrue)
+			So(r, hasLowerBound, MustParse("1.2.0"))
+			So(r, isRightClosedBy, MustParse("1.5.0"))
+		})
+
+		Convey("is commutative", func() {
+			a, b := mustRange(">=1.2.3 <2"), mustRange(">1.4.0 <=3")
+			ab, _ := a.Intersect(b)
+			ba, _ := b.Intersect(a)
+			testIfResembles(ab, ba)
+			So(ab, isLeftClosedBy, MustParse("1.4.0"))
+			So(ab, isRightClosedBy, MustParse("2.0.0"))
+		})
+
+		Convey("takes the exclusive boundary at the same Version", func() {
+			r, ok := mustRange(">=1.2.3").Intersect(mustRange(">1.2.3"))
+			So(ok, ShouldBeTrue)
+			So(r, isLeftClosedBy, MustParse("1.2.3"))
+		})
+
+		Convey("with everything is a no-op", func() {
+			a := mustRange("~1.2.3")
+			r, ok := a.Intersect(Range{})
+			So(ok, ShouldBeTrue)
+			testIfResembles(r, a)
+		})
+
+		Convey("disjoint Ranges is unsatisfiable", func() {
+			_, ok := mustRange("^1.2").Intersect(mustRange("^2.0"))
+			So(ok, ShouldBeFalse)
+			_, ok = mustRange("<1.2.3").Intersect(mustRange(">=1.2.3"))
+			So(ok, ShouldBeFalse)
+			_, ok = mustRange(">=1.2.3-rc1").Intersect(mustRange("<1.2.3"))
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("tells pre-release identifiers apart", func() {
+			r, ok := mustRange(">=1.0.0-alpha.x").Intersect(mustRange(">=1.0.0-alpha.y"))
+			So(ok, ShouldBeTrue)
+			So(r, hasLowerBound, mustVersion("1.0.0-alpha.y"))
+			_, ok = mustRange("<1.0.0-alpha.x").Intersect(mustRange(">=1.0.0-alpha.y"))
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("keeps the pre-release rules of the chosen boundary", func() {
+			r, ok := mustRange(">=1.2.3-rc1").Intersect(mustRange("<2"))
+			So(ok, ShouldBeTrue)
+			So(r.IsSatisfiedBy(MustParse("1.2.3-rc2")), ShouldBeTrue)
+			So(r.IsSatisfiedBy(MustParse("1.4.0-rc2")), ShouldBeFalse)
+		})
+	})
+}
+
+func TestRangeUnion(t *testing.T) {
+	Convey("The union of…", t, func() {
+		Convey("overlapping Ranges is one Range", func() {
+			set := mustRange("^1.2").Union(mustRange(">=1.8 <3"))
+			So(set, ShouldHaveLength, 1)
+			So(set[0], hasLowerBound, MustParse("1.2.0"))
+			So(set[0], isRightClosedBy, MustParse("3.0.0"))
+		})
+
+		Convey("adjoining Ranges is one Range", func() {
+			set := mustRange("<=1.2.3").Union(mustRange(">1.2.3"))
+			So(set, ShouldHaveLength, 1)
+			So(set[0].GetLowerBoundary(), ShouldBeNil)
+			So(set[0].GetUpperBoundary(), ShouldBeNil)
+		})
+
+		Convey("disjoint Ranges are both, in order", func() {
+			set := mustRange("^3").Union(mustRange("^1.2"))
+			So(set, ShouldHaveLength, 2)
+			So(set[0], hasLowerBound, MustParse("1.2.0"))
+			So(set[1], hasLowerBound, MustParse("3.0.0"))
+		})
+
+		Convey("a Range and an empty one is the former", func() {
+			set := mustRange(">2 <1").Union(mustRange("^1.2"))
+			So(set, ShouldHaveLength, 1)
+			testIfResembles(set[0], mustRange("^1.2"))
+		})
+	})
+}
+
+func TestRangeComplement(t *testing.T) {
+	Convey("The complement of…", t, func() {
+		Convey("^1.2 is <1.2.0, the pre-releases of 1.2.0, and >=2.0.0-0", func() {
+			set := mustRange("^1.2").Complement()
+			So(set, ShouldHaveLength, 3)
+			So(set[0], isRightClosedBy, MustParse("1.2.0"))
+			So(set[1], hasLowerBound, mustVersion("1.2.0-0"))
+			So(set[1], isRightClosedBy, MustParse("1.2.0"))
+			So(set[2], hasLowerBound, mustVersion("2.0.0-0"))
+			So(set.Contains(MustParse("1.1.9")), ShouldBeTrue)
+			So(set.Contains(MustParse("1.2.0-rc1")), ShouldBeTrue)
+			So(set.Contains(MustParse("1.2.0")), ShouldBeFalse)
+			So(set.Contains(MustParse("2.0.0-rc1")), ShouldBeTrue)
+			So(set.Contains(MustParse("2.0.0")), ShouldBeTrue)
+		})
+
+		Convey("an exact Version excludes only that", func() {
+			set := mustRange("1.2.3").Complement()
+			So(set, ShouldHaveLength, 3)
+			So(set.Contains(MustParse("1.2.3")), ShouldBeFalse)
+			So(set.Contains(MustParse("1.2.3-p1")), ShouldBeFalse)
+			So(set.Contains(MustParse("1.2.4")), ShouldBeTrue)
+		})
+
+		Convey("everything is nothing, and vice versa", func() {
+			So(Range{}.Complement(), ShouldHaveLength, 0)
+			set := mustRange(">2 <1").Complement()
+			So(set, ShouldHaveLength, 1)
+			So(set.Contains(MustParse("1.5")), ShouldBeTrue)
+		})
+
+		Convey("any Range has every Version in either", func() {
+			versions := []string{
+				"0.9.0", "1.0.0-alpha.x", "1.0.0-alpha.xx", "1.0.0-alpha.y", "1.0.0-rc1", "1.0.0", "1.0.0-p1",
+				"1.2.0-rc1", "1.2.3-rc1", "1.2.3-rc2", "1.2.3", "1.2.3-p1", "2.0.0-rc1", "2.0.0", "2.0.0-p1", "3.0.0",
+			}
+			for _, str := range []string{
+				">=1.0.0", ">1.0.0", "<1.0.0", "<=1.0.0", "^1.2", "1.2.3", ">1.2.3-rc1 <=2",
+				">=1.0.0-alpha.x <1.0.0-alpha.y", ">=1.0.0-rc1 <1.0.0", ">=1.0.0-p1",
+			} {
+				r := mustRange(str)
+				set := r.Complement()
+				for _, version := range versions {
+					v := mustVersion(version)
+					So(set.Contains(v), ShouldNotEqual, r.Contains(v))
+				}
+			}
+		})
+	})
+}
+
+func TestRangeIsSubsetOf(t *testing.T) {
+	Convey("IsSubsetOf…", t, func() {
+		So(mustRange("~1.2.3").IsSubsetOf(mustRange("^1.2")), ShouldBeTrue)
+		So(mustRange("^1.2").IsSubsetOf(mustRange("~1.2.3")), ShouldBeFalse)
+		So(mustRange("1.2.3").IsSubsetOf(mustRange(">=1.2.3")), ShouldBeTrue)
+		So(mustRange("1.2.3").IsSubsetOf(mustRange(">1.2.3")), ShouldBeFalse)
+		So(mustRange("<=1.2.3").IsSubsetOf(mustRange("<1.2.3")), ShouldBeFalse)
+		So(mustRange("^1.2").IsSubsetOf(Range{}), ShouldBeTrue)
+		So(mustRange(">2 <1").IsSubsetOf(mustRange("1.0")), ShouldBeTrue)
+	})
+}
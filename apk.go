@@ -0,0 +1,227 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"database/sql/driver"
+	"sort"
+	"strings"
+)
+
+// Errors that are thrown when reading an ApkVersion.
+const (
+	errInvalidApkVersion InvalidStringValue = "Given string does not resemble a version of an Alpine package"
+	errInvalidApkType    InvalidStringValue = "Cannot read this type into an ApkVersion"
+)
+
+// apkSuffixes are the suffixes apk-tools knows, in ascending order.
+// Those before "cvs" mark pre-releases.
+var apkSuffixes = [...]string{"alpha", "beta", "pre", "rc", "cvs", "svn", "git", "hg", "p"}
+
+// apkFirstPostSuffix is the index of the first suffix in apkSuffixes that sorts after none.
+const apkFirstPostSuffix = 4
+
+// ApkVersion is a version of an Alpine package, such as "1.36.1_git20240101-r0",
+// in the format "{digit}{.digit}…{letter}{_suffix{number}}…{~hash}{-r#}" of apk-tools.
+//
+// This is the notation of GentooVersion with more suffixes,
+// of which _cvs, _svn, _git, _hg and _p sort after none, and a commit hash.
+type ApkVersion struct {
+	str      string
+	numbers  []string
+	letter   byte
+	suffixes []gentooSuffix
+	hash     string
+	revision string
+}
+
+// NewApkVersion reads a version of an Alpine package, without the package name.
+func NewApkVersion(str []byte) (ApkVersion, error) {
+	v := ApkVersion{str: string(str)}
+	var idx int
+	for {
+		n := countDigits(str[idx:])
+		if n == 0 {
+			return ApkVersion{}, newParseError(errInvalidApkVersion, str, idx, "a digit")
+		}
+		v.numbers = append(v.numbers, v.str[idx:idx+n])
+		idx += n
+		if idx >= len(str) || str[idx] != '.' {
+			break
+		}
+		idx++
+	}
+	if idx < len(str) && isSmallLetter(str[idx]) {
+		v.letter = str[idx]
+		idx++
+	}
+
+	for idx < len(str) && str[idx] == '_' {
+		idx++
+		kind := -1
+		for i, suffix := range apkSuffixes {
+			if strings.HasPrefix(v.str[idx:], suffix) {
+				kind = i
+				break
+			}
+		}
+		if kind < 0 {
+			return ApkVersion{}, newParseError(errInvalidApkVersion, str, idx, "alpha, beta, pre, rc, cvs, svn, git, hg, or p")
+		}
+		idx += len(apkSuffixes[kind])
+		n := countDigits(str[idx:])
+		v.suffixes = append(v.suffixes, gentooSuffix{kind: kind, number: v.str[idx : idx+n]})
+		idx += n
+	}
+
+	if idx < len(str) && str[idx] == '~' {
+		idx++
+		start := idx
+		for idx < len(str) && (isNumeric(str[idx]) || ('a' <= str[idx] && str[idx] <= 'f')) {
+			idx++
+		}
+		if idx == start {
+			return ApkVersion{}, newParseError(errInvalidApkVersion, str, idx, "a hexadecimal digit")
+		}
+		v.hash = v.str[start:idx]
+	}
+
+	if idx+1 < len(str) && str[idx] == '-' && str[idx+1] == 'r' {
+		idx += 2
+		n := countDigits(str[idx:])
+		if n == 0 {
+			return ApkVersion{}, newParseError(errInvalidApkVersion, str, idx, "a digit")
+		}
+		v.revision = v.str[idx : idx+n]
+		idx += n
+	}
+
+	if idx < len(str) {
+		return ApkVersion{}, newParseError(errInvalidApkVersion, str, idx, "'_', '~', \"-r\", or the end")
+	}
+	return v, nil
+}
+
+// ParseApk is NewApkVersion for strings.
+func ParseApk(str string) (ApkVersion, error) {
+	return NewApkVersion([]byte(str))
+}
+
+// IsPreRelease is true if the ApkVersion has a suffix _alpha, _beta, _pre, or _rc.
+func (v ApkVersion) IsPreRelease() bool {
+	for _, suffix := range v.suffixes {
+		if suffix.kind < apkFirstPostSuffix {
+			return true
+		}
+	}
+	return false
+}
+
+// Revision returns the number of the package's revision, such as "4" in "1.2.3-r4",
+// or the empty string if there's none.
+func (v ApkVersion) Revision() string {
+	return v.revision
+}
+
+// Compare returns the signum of the difference between v and o, as apk-tools orders them:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// The numbers are compared as in GentooVersion, then the letter, then the suffixes:
+// 1.0_alpha < 1.0_beta < 1.0_pre < 1.0_rc < 1.0 < 1.0_cvs < 1.0_svn < 1.0_git < 1.0_hg < 1.0_p,
+// then the revision. The commit hash doesn't take part in the order.
+func (v ApkVersion) Compare(o ApkVersion) int {
+	if len(v.numbers) == 0 || len(o.numbers) == 0 { // The zero value is the lowest.
+		return signum(len(v.numbers) - len(o.numbers))
+	}
+
+	if c := compareGentooNumbers(v.numbers, o.numbers); c != 0 {
+		return c
+	}
+	if c := signum(int(v.letter) - int(o.letter)); c != 0 {
+		return c
+	}
+	if c := compareGentooSuffixes(v.suffixes, o.suffixes, apkFirstPostSuffix); c != 0 {
+		return c
+	}
+	return compareDecimals(v.revision, o.revision)
+}
+
+// Less is a convenience function for sorting.
+func (v *ApkVersion) Less(o *ApkVersion) bool {
+	return v.Compare(*o) < 0
+}
+
+// String returns the version as it has been read.
+func (v ApkVersion) String() string {
+	return v.str
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v ApkVersion) MarshalJSON() ([]byte, error) {
+	return quoteJSON(v.str), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v ApkVersion) MarshalText() ([]byte, error) {
+	return []byte(v.str), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *ApkVersion) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, v.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *ApkVersion) UnmarshalText(b []byte) error {
+	av, err := NewApkVersion(b)
+	if err != nil {
+		return err
+	}
+	*v = av
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *ApkVersion) Scan(src interface{}) error {
+	return scanText(src, v.UnmarshalText, errInvalidApkType)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (v ApkVersion) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// ApkVersionPtrs represents an array with elements derived from ApkVersion.
+// Use it to sort them, in the order apk-tools does.
+type ApkVersionPtrs []*ApkVersion
+
+// Len implements the sort.Interface.
+func (p ApkVersionPtrs) Len() int {
+	return len(p)
+}
+
+// Swap implements the sort.Interface.
+func (p ApkVersionPtrs) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements the sort.Interface.
+func (p ApkVersionPtrs) Less(i, j int) bool {
+	if p[i] == nil {
+		return false
+	} else if p[j] == nil {
+		return true
+	}
+	return p[i].Less(p[j])
+}
+
+// Sort reorders the pointers so that the ApkVersions appear in ascending order.
+// Any nil pointers go last.
+func (p ApkVersionPtrs) Sort() {
+	sort.Stable(p)
+}
@@ -0,0 +1,134 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParseApk(str string) ApkVersion {
+	v, err := ParseApk(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestNewApkVersion(t *testing.T) {
+	Convey("NewApkVersion works with…", t, FailureContinues, func() {
+		for _, str := range []string{
+			"0", "1.2.3-r4", "1.36.1_git20240101-r0", "2.0a_rc1_p2", "1.0_cvs", "1.0_hg3", "1.0~deadbeef-r1",
+			"20240101", "1.0_pre_pre",
+		} {
+			Convey(str, func() {
+				v, err := ParseApk(str)
+				So(err, ShouldBeNil)
+				So(v.String(), ShouldEqual, str)
+			})
+		}
+	})
+
+	Convey("NewApkVersion rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":         0,
+			"v1.0":     0,
+			"1.":       2,
+			"1.0ab":    4,
+			"1.0A":     3,
+			"1.0_foo":  4,
+			"1.0~":     4,
+			"1.0~xyz":  4,
+			"1.0-r":    5,
+			"1.0-1":    3,
+			"1.0-r1_p": 6,
+			"1:1.0":    1,
+		} {
+			Convey(str, func() {
+				_, err := ParseApk(str)
+				So(errors.Is(err, errInvalidApkVersion), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
+
+func TestApkVersionOrder(t *testing.T) {
+	ordered := []string{
+		"0.9", "1.0_alpha", "1.0_alpha1", "1.0_beta", "1.0_pre", "1.0_rc1", "1.0_rc2", "1.0", "1.0-r1",
+		"1.0_cvs", "1.0_svn", "1.0_git20240101", "1.0_hg", "1.0_p1", "1.0_p2", "1.0a", "1.01", "1.1",
+		"1.36.1-r5", "1.36.1_git20240101-r0", "2",
+	}
+
+	Convey("ApkVersions are ordered as by apk-tools", t, func() {
+		for i := 0; i+1 < len(ordered); i++ {
+			a, b := mustParseApk(ordered[i]), mustParseApk(ordered[i+1])
+			So(a.Compare(b), ShouldEqual, -1)
+			So(b.Compare(a), ShouldEqual, 1)
+		}
+		for a, b := range map[string]string{
+			"1.0":     "1.0-r0",
+			"1.0~abc": "1.0~def",
+			"1.010":   "1.01",
+		} {
+			So(mustParseApk(a).Compare(mustParseApk(b)), ShouldEqual, 0)
+		}
+
+		Convey("also by ApkVersionPtrs, nil last", func() {
+			ptrs := ApkVersionPtrs{nil}
+			for i := len(ordered) - 1; i >= 0; i-- {
+				v := mustParseApk(ordered[i])
+				ptrs = append(ptrs, &v)
+			}
+			ptrs.Sort()
+			for i := range ordered {
+				So(ptrs[i].String(), ShouldEqual, ordered[i])
+			}
+			So(ptrs[len(ordered)], ShouldBeNil)
+		})
+	})
+
+	Convey("ApkVersions know…", t, func() {
+		So(mustParseApk("1.0_rc1-r2").IsPreRelease(), ShouldBeTrue)
+		So(mustParseApk("1.0_p1_beta2").IsPreRelease(), ShouldBeTrue)
+		So(mustParseApk("1.0_git20240101").IsPreRelease(), ShouldBeFalse)
+		So(mustParseApk("1.0_rc1-r2").Revision(), ShouldEqual, "2")
+		So(mustParseApk("1.0").Revision(), ShouldEqual, "")
+	})
+
+	Convey("ApkVersion is written as it's read…", t, FailureContinues, func() {
+		Convey("with the commit hash, which doesn't take part in the order", func() {
+			v := mustParseApk("1.36.1_git20240101~0a1b2c3-r0")
+			out, err := json.Marshal(v)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `"1.36.1_git20240101~0a1b2c3-r0"`)
+			var back ApkVersion
+			So(json.Unmarshal([]byte(`"1.36.1_git20240101~fff-r0"`), &back), ShouldBeNil)
+			So(back.Compare(v), ShouldEqual, 0)
+			So(back.String(), ShouldNotEqual, v.String())
+		})
+
+		Convey("and left alone by JSON null", func() {
+			v := mustParseApk("1.0_rc1-r2")
+			So(json.Unmarshal([]byte("null"), &v), ShouldBeNil)
+			So(v.Revision(), ShouldEqual, "2")
+		})
+	})
+
+	Convey("ApkVersion is scanned from databases, such as the V: lines of an APKINDEX", t, func() {
+		var back ApkVersion
+		So(back.Scan([]byte("1.36.1-r15")), ShouldBeNil)
+		So(back.Revision(), ShouldEqual, "15")
+		val, _ := back.Value()
+		So(val, ShouldEqual, "1.36.1-r15")
+		So(errors.Is(back.Scan("1.36.1-r"), errInvalidApkVersion), ShouldBeTrue)
+		So(back.Scan(3.14), ShouldEqual, errInvalidApkType)
+	})
+}
@@ -0,0 +1,300 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"strings"
+)
+
+const errInvalidAtom InvalidStringValue = "Given string does not resemble a Gentoo package atom"
+
+// atomOperators in the order they are tried, the longer before any shorter one.
+var atomOperators = [...]string{"<=", ">=", "<", ">", "=", "~"}
+
+// Atom is a dependency specification of Gentoo Linux, such as ">=dev-lang/go-1.16:0/1.16="
+// or "!!<net-misc/baz-3", as defined by its Package Manager Specification (PMS) §8.3.
+type Atom struct {
+	blocker      string
+	operator     string
+	category     string
+	pkg          string
+	version      GentooVersion
+	hasVersion   bool
+	slot         string
+	subSlot      string
+	slotOperator string
+}
+
+// NewAtom reads an Atom, which consists of, in this order:
+// An optional blocker "!" or "!!", an operator if a version follows,
+// the category and package name separated by a slash, "-" and the version,
+// which is optionally followed by "*" for the operator "=",
+// and a slot with an optional sub-slot and slot operator, such as ":0/1.16=", ":=" or ":*".
+//
+// USE dependencies and repositories are not supported.
+func NewAtom(str []byte) (Atom, error) {
+	var a Atom
+	var idx int
+	switch {
+	case bytes.HasPrefix(str, []byte("!!")):
+		a.blocker, idx = "!!", 2
+	case bytes.HasPrefix(str, []byte("!")):
+		a.blocker, idx = "!", 1
+	}
+	for _, operator := range atomOperators {
+		if bytes.HasPrefix(str[idx:], []byte(operator)) {
+			a.operator = operator
+			idx += len(operator)
+			break
+		}
+	}
+
+	end := len(str)
+	if colon := bytes.IndexByte(str, ':'); colon >= 0 {
+		if err := a.setSlot(str, colon+1); err != nil {
+			return Atom{}, err
+		}
+		end = colon
+	}
+
+	slash := bytes.IndexByte(str[idx:end], '/')
+	if slash < 0 {
+		return Atom{}, newParseError(errInvalidAtom, str, end, "a category, '/', and a package name")
+	}
+	slash += idx
+	if n := countNameBytes(str[idx:slash], true); n == 0 || idx+n < slash {
+		return Atom{}, newParseError(errInvalidAtom, str, idx+n, "a category name")
+	}
+	a.category = string(str[idx:slash])
+	idx = slash + 1
+
+	if end > idx && str[end-1] == '*' {
+		if a.operator != "=" {
+			return Atom{}, newParseError(errInvalidAtom, str, end-1, "'=' as operator for a wildcard")
+		}
+		a.operator = "=*"
+		end--
+	}
+
+	versionIdx := findAtomVersion(str[idx:end])
+	if a.operator == "" {
+		if versionIdx >= 0 {
+			return Atom{}, newParseError(errInvalidAtom, str, 0, "an operator, as a version follows")
+		}
+		if n := countNameBytes(str[idx:end], false); n == 0 || idx+n < end {
+			return Atom{}, newParseError(errInvalidAtom, str, idx+n, "a package name")
+		}
+		a.pkg = string(str[idx:end])
+		return a, nil
+	}
+
+	if versionIdx < 0 {
+		return Atom{}, newParseError(errInvalidAtom, str, end, "'-' and a version, as an operator precedes")
+	}
+	a.pkg = string(str[idx : idx+versionIdx-1])
+	a.version, _ = NewGentooVersion(str[idx+versionIdx : end])
+	a.hasVersion = true
+	return a, nil
+}
+
+// ParseAtom is NewAtom for strings.
+func ParseAtom(str string) (Atom, error) {
+	return NewAtom([]byte(str))
+}
+
+// setSlot reads the slot, sub-slot and slot operator which begin at idx.
+func (a *Atom) setSlot(str []byte, idx int) error {
+	switch string(str[idx:]) {
+	case "*", "=":
+		a.slotOperator = string(str[idx:])
+		return nil
+	}
+
+	n := countNameBytes(str[idx:], true)
+	if n == 0 {
+		return newParseError(errInvalidAtom, str, idx, "a slot name, '*', or '='")
+	}
+	a.slot, idx = string(str[idx:idx+n]), idx+n
+	if idx < len(str) && str[idx] == '/' {
+		idx++
+		n = countNameBytes(str[idx:], true)
+		if n == 0 {
+			return newParseError(errInvalidAtom, str, idx, "a sub-slot name")
+		}
+		a.subSlot, idx = string(str[idx:idx+n]), idx+n
+	}
+	if idx < len(str) && str[idx] == '=' {
+		a.slotOperator = "="
+		idx++
+	}
+	if idx < len(str) {
+		return newParseError(errInvalidAtom, str, idx, "'/', '=', or the end")
+	}
+	return nil
+}
+
+// countNameBytes returns the length of the leading category, package, or slot name,
+// which begins with [A-Za-z0-9_] followed by any of [A-Za-z0-9+_-].
+// Categories and slots can have dots, too.
+func countNameBytes(str []byte, withDots bool) int {
+	for n, ch := range str {
+		switch {
+		case isNumeric(ch), isSmallLetter(ch|0x20), ch == '_':
+		case n > 0 && (ch == '+' || ch == '-'):
+		case n > 0 && withDots && ch == '.':
+		default:
+			return n
+		}
+	}
+	return len(str)
+}
+
+// findAtomVersion returns the index of the version in "package-version",
+// or -1 if there is none.
+//
+// A package name must not end in a hyphen followed by a version,
+// hence the first such hyphen separates them.
+func findAtomVersion(str []byte) int {
+	for idx := bytes.IndexByte(str, '-'); idx > 0; {
+		if _, err := NewGentooVersion(str[idx+1:]); err == nil {
+			if countNameBytes(str[:idx], false) == idx {
+				return idx + 1
+			}
+			return -1
+		}
+		next := bytes.IndexByte(str[idx+1:], '-')
+		if next < 0 {
+			break
+		}
+		idx += 1 + next
+	}
+	return -1
+}
+
+// Category returns the category, such as "dev-lang".
+func (a Atom) Category() string {
+	return a.category
+}
+
+// Package returns the package name, such as "go".
+func (a Atom) Package() string {
+	return a.pkg
+}
+
+// Version returns the version, or nil if the Atom has none.
+func (a Atom) Version() *GentooVersion {
+	if !a.hasVersion {
+		return nil
+	}
+	return &a.version
+}
+
+// Operator returns one of "<", "<=", "=", "~", ">=", ">", "=*" for "=" followed by a wildcard,
+// or the empty string if the Atom has no version.
+func (a Atom) Operator() string {
+	return a.operator
+}
+
+// Blocker returns "!" for a weak and "!!" for a strong blocker, else the empty string.
+func (a Atom) Blocker() string {
+	return a.blocker
+}
+
+// Slot returns the slot and sub-slot, which are empty if not given.
+func (a Atom) Slot() (slot, subSlot string) {
+	return a.slot, a.subSlot
+}
+
+// SlotOperator returns "=" or "*" if the Atom has any, else the empty string.
+func (a Atom) SlotOperator() string {
+	return a.slotOperator
+}
+
+// String returns the Atom in its canonical notation.
+func (a Atom) String() string {
+	var b strings.Builder
+	b.WriteString(a.blocker)
+	if a.operator == "=*" {
+		b.WriteByte('=')
+	} else {
+		b.WriteString(a.operator)
+	}
+	b.WriteString(a.category)
+	b.WriteByte('/')
+	b.WriteString(a.pkg)
+	if a.hasVersion {
+		b.WriteByte('-')
+		b.WriteString(a.version.String())
+		if a.operator == "=*" {
+			b.WriteByte('*')
+		}
+	}
+	if a.slot != "" || a.slotOperator != "" {
+		b.WriteByte(':')
+		b.WriteString(a.slot)
+		if a.subSlot != "" {
+			b.WriteByte('/')
+			b.WriteString(a.subSlot)
+		}
+		b.WriteString(a.slotOperator)
+	}
+	return b.String()
+}
+
+// IsSatisfiedBy is true if the given version of the package matches the version part of the Atom,
+// which any does if it has none. It plays the role of Range's IsSatisfiedBy for Gentoo.
+//
+// Both the category and package name, as well as any blocker and slot, are left for you to check.
+func (a Atom) IsSatisfiedBy(v GentooVersion) bool {
+	switch a.operator {
+	case "":
+		return true
+	case "<":
+		return v.Compare(a.version) < 0
+	case "<=":
+		return v.Compare(a.version) <= 0
+	case "=":
+		return v.Compare(a.version) == 0
+	case "~":
+		return v.withoutRevision().Compare(a.version.withoutRevision()) == 0
+	case ">=":
+		return v.Compare(a.version) >= 0
+	case ">":
+		return v.Compare(a.version) > 0
+	case "=*":
+		return v.hasPrefix(a.version)
+	}
+	return false
+}
+
+func (v GentooVersion) withoutRevision() GentooVersion {
+	v.revision = ""
+	return v
+}
+
+// hasPrefix implements the wildcard of "=cat/pkg-1.2*" as Portage does:
+// The version must start with the given string, and there a part must end,
+// hence "1.2*" matches 1.2, 1.2.3 and 1.2b, but not 1.20.
+// The first component is read without leading zeroes.
+func (v GentooVersion) hasPrefix(prefix GentooVersion) bool {
+	str, p := trimLeadingZeroes(v.str), trimLeadingZeroes(prefix.str)
+	if !strings.HasPrefix(str, p) || p == "" {
+		return false
+	}
+	if len(str) == len(p) {
+		return true
+	}
+	next := str[len(p)]
+	return next == '.' || next == '_' || next == '-' || isNumeric(next) != isNumeric(p[len(p)-1])
+}
+
+func trimLeadingZeroes(str string) string {
+	trimmed := strings.TrimLeft(str, "0")
+	if trimmed == "" || !isNumeric(trimmed[0]) {
+		return "0" + trimmed
+	}
+	return trimmed
+}
@@ -0,0 +1,130 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewAtom(t *testing.T) {
+	Convey("NewAtom reads…", t, func() {
+		Convey("an operator, version, slot, sub-slot and slot operator", func() {
+			a, err := ParseAtom(">=dev-lang/go-1.16:0/1.16=")
+			So(err, ShouldBeNil)
+			So(a.Blocker(), ShouldBeEmpty)
+			So(a.Operator(), ShouldEqual, ">=")
+			So(a.Category(), ShouldEqual, "dev-lang")
+			So(a.Package(), ShouldEqual, "go")
+			So(a.Version().String(), ShouldEqual, "1.16")
+			slot, subSlot := a.Slot()
+			So(slot, ShouldEqual, "0")
+			So(subSlot, ShouldEqual, "1.16")
+			So(a.SlotOperator(), ShouldEqual, "=")
+		})
+
+		Convey("a wildcard", func() {
+			a, err := ParseAtom("=sys-libs/bar-2.1*")
+			So(err, ShouldBeNil)
+			So(a.Operator(), ShouldEqual, "=*")
+			So(a.Version().String(), ShouldEqual, "2.1")
+		})
+
+		Convey("blockers", func() {
+			a, err := ParseAtom("!!<net-misc/baz-3")
+			So(err, ShouldBeNil)
+			So(a.Blocker(), ShouldEqual, "!!")
+			So(a.Operator(), ShouldEqual, "<")
+			a, err = ParseAtom("!app-misc/foo")
+			So(err, ShouldBeNil)
+			So(a.Blocker(), ShouldEqual, "!")
+			So(a.Version(), ShouldBeNil)
+		})
+
+		Convey("package names with hyphens and versions with revisions", func() {
+			a, err := ParseAtom("~app-misc/foo-bar-2-1.2-r3")
+			So(err, ShouldBeNil)
+			So(a.Package(), ShouldEqual, "foo-bar-2")
+			So(a.Version().String(), ShouldEqual, "1.2-r3")
+		})
+
+		Convey("slot operators alone", func() {
+			for _, str := range []string{"dev-libs/openssl:=", "dev-libs/openssl:*", "dev-libs/openssl:0="} {
+				a, err := ParseAtom(str)
+				So(err, ShouldBeNil)
+				So(a.String(), ShouldEqual, str)
+			}
+		})
+
+		Convey("and writes them back as String", func() {
+			for _, str := range []string{
+				">=dev-lang/go-1.16:0/1.16=", "~app-misc/foo-1.2", "=sys-libs/bar-2.1*",
+				"!!<net-misc/baz-3", "dev-qt/qtcore:5", "<=x11-libs/gtk+-3.24.5-r1:3",
+			} {
+				a, err := ParseAtom(str)
+				So(err, ShouldBeNil)
+				So(a.String(), ShouldEqual, str)
+			}
+		})
+	})
+
+	Convey("NewAtom rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":                      0,
+			"go":                    2,
+			"dev-lang/go-1.16":      0,
+			">=dev-lang/go":         13,
+			">=dev-lang/go-":        14,
+			"dev-lang/go*":          11,
+			">=dev-lang/go-1.16*":   18,
+			"=dev-lang/go-1.16:":    18,
+			"=dev-lang/go-1.16:0/":  20,
+			"=dev-lang/go-1.16:0=x": 20,
+			"-dev/go":               0,
+			"dev-lang/g@":           10,
+			"dev-lang/go[ssl]":      11,
+		} {
+			Convey(str, func() {
+				_, err := ParseAtom(str)
+				So(errors.Is(err, errInvalidAtom), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
+
+func TestAtomIsSatisfiedBy(t *testing.T) {
+	Convey("IsSatisfiedBy works with…", t, FailureContinues, func() {
+		for atom, versions := range map[string]map[string]bool{
+			"app-misc/foo": {"0": true, "1.2": true},
+			">=dev-lang/go-1.16": {
+				"1.15.9": false, "1.16_rc1": false, "1.16": true, "1.16-r1": true, "1.17": true,
+			},
+			">dev-lang/go-1.16": {"1.16": false, "1.16-r0": false, "1.16-r1": true},
+			"<net-misc/baz-3":   {"2.9": true, "3_rc1": true, "3": false, "3.0": false},
+			"<=net-misc/baz-3":  {"3": true, "3-r1": false, "3_p1": false},
+			"=app-misc/foo-1.2": {"1.2": true, "1.2-r0": true, "1.2-r1": false, "1.20": false},
+			"~app-misc/foo-1.2": {"1.2": true, "1.2-r7": true, "1.2.1": false, "1.2_p1": false},
+			"=sys-libs/bar-2.1*": {
+				"2.1": true, "2.1.3": true, "2.1b": true, "2.1_rc1": true, "2.1-r1": true,
+				"2.10": false, "2.2": false, "2": false,
+			},
+			"=sys-libs/bar-02.1*": {"2.1.3": true, "002.1": true},
+			"=sys-libs/bar-2*":    {"2": true, "2.1": true, "20": false},
+		} {
+			a, err := ParseAtom(atom)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(atom+" and "+version, func() {
+					So(a.IsSatisfiedBy(mustParseGentoo(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+// IncMajor returns the next major Version, such as 2.0.0 for 1.2.3.
+//
+// A pre-release of a major Version, such as 2.0.0-rc1, becomes that very
+// Version 2.0.0. Any build metadata is dropped.
+func (t Version) IncMajor() Version {
+	return t.incColumn(0)
+}
+
+// IncMinor returns the next minor Version, such as 1.3.0 for 1.2.3.
+//
+// A pre-release of a minor Version, such as 1.3.0-rc1, becomes that very
+// Version 1.3.0. Any build metadata is dropped.
+func (t Version) IncMinor() Version {
+	return t.incColumn(1)
+}
+
+// IncPatch returns the next patch Version, such as 1.2.4 for 1.2.3 or 1.2.3-p1.
+//
+// A pre-release, such as 1.2.4-rc1, becomes its release 1.2.4.
+// Any build metadata is dropped.
+func (t Version) IncPatch() Version {
+	return t.incColumn(2)
+}
+
+// IncRevision returns the Version with its fourth column incremented,
+// such as 1.2.3.1 for 1.2.3.
+//
+// That is not the release type 'r' in 1.2.3-r1. Use that for packaging,
+// and this for projects that release in four columns.
+func (t Version) IncRevision() Version {
+	return t.incColumn(3)
+}
+
+// incColumn increments the column at idx and resets all following fields,
+// unless t is a pre-release of the thus incremented Version, in which case that is returned.
+func (t Version) incColumn(idx int) Version {
+	next := Version{}
+	copy(next.version[:idxReleaseType], t.version[:idxReleaseType])
+	if t.IsAPreRelease() {
+		isPreReleaseOfNext := true
+		for _, n := range t.version[idx+1 : idxReleaseType] {
+			isPreReleaseOfNext = isPreReleaseOfNext && n == 0
+		}
+		if isPreReleaseOfNext {
+			return next
+		}
+	}
+
+	next.version[idx]++
+	for i := idx + 1; i < idxReleaseType; i++ {
+		next.version[i] = 0
+	}
+	return next
+}
+
+// IncPrerelease returns the next pre-release of the given type,
+// which is one of "alpha", "beta", "pre", or "rc".
+//
+// A pre-release of the same type is counted up, 1.2.4-rc1 becomes 1.2.4-rc2,
+// and a lower one moves up to the given type, 1.2.4-beta3 becomes 1.2.4-rc1.
+// Anything else results in a pre-release of the next patch Version:
+// 1.2.3 becomes 1.2.4-rc1, and 1.2.4-rc2 asked for "beta" becomes 1.2.5-beta1.
+//
+// Hence the returned Version is always greater than t. Any build metadata is dropped.
+func (t Version) IncPrerelease(releaseType string) (Version, error) {
+	typ, known := releaseValue[releaseType]
+	if !known || releaseType == "" || typ >= common {
+		return t, errInvalidPreReleaseType
+	}
+
+	next := Version{}
+	copy(next.version[:idxReleaseType], t.version[:idxReleaseType])
+	next.version[idxReleaseType] = int32(typ)
+	next.version[idxRelease] = 1
+	if t.version[idxReleaseType] == int32(typ) {
+		next.version[idxRelease] = t.version[idxRelease] + 1
+	}
+	if Compare(&next, &t) > 0 {
+		return next, nil
+	}
+
+	next.version[2]++
+	next.version[3] = 0
+	next.version[idxRelease] = 1
+	return next, nil
+}
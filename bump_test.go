@@ -0,0 +1,88 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIncColumns(t *testing.T) {
+	type expectation struct {
+		major, minor, patch, revision string
+	}
+
+	Convey("Incrementing works with…", t, FailureContinues, func() {
+		for str, exp := range map[string]expectation{
+			"1.2.3":          {"2.0.0", "1.3.0", "1.2.4", "1.2.3.1"},
+			"1.2.3.4":        {"2.0.0", "1.3.0", "1.2.4", "1.2.3.5"},
+			"0.0.0":          {"1.0.0", "0.1.0", "0.0.1", "0.0.0.1"},
+			"1.2.3-p1":       {"2.0.0", "1.3.0", "1.2.4", "1.2.3.1"},
+			"1.2.3-r2":       {"2.0.0", "1.3.0", "1.2.4", "1.2.3.1"},
+			"1.2.3+build5":   {"2.0.0", "1.3.0", "1.2.4", "1.2.3.1"},
+			"2.0.0-rc1":      {"2.0.0", "2.0.0", "2.0.0", "2.0.0"},
+			"1.3.0-beta2":    {"2.0.0", "1.3.0", "1.3.0", "1.3.0"},
+			"1.2.4-alpha":    {"2.0.0", "1.3.0", "1.2.4", "1.2.4"},
+			"1.2.3.4-rc1":    {"2.0.0", "1.3.0", "1.2.4", "1.2.3.4"},
+			"1.2.3-rc1-p2":   {"2.0.0", "1.3.0", "1.2.3", "1.2.3"},
+			"1.2.0-rc1+sha5": {"2.0.0", "1.2.0", "1.2.0", "1.2.0"},
+		} {
+			Convey(str, func() {
+				v, err := newVocabularyVersion([]byte(str))
+				So(err, ShouldBeNil)
+				So(v.IncMajor().String(), ShouldEqual, exp.major)
+				So(v.IncMinor().String(), ShouldEqual, exp.minor)
+				So(v.IncPatch().String(), ShouldEqual, exp.patch)
+				So(v.IncRevision().String(), ShouldEqual, exp.revision)
+			})
+		}
+
+		Convey("pre-release identifiers", func() {
+			v, _ := NewVersionWithIdentifiers([]byte("1.0.0-SNAPSHOT+sha.5114f85"))
+			next := v.IncPatch()
+			So(next.String(), ShouldEqual, "1.0.0")
+			So(next.Build(), ShouldBeEmpty)
+			So(next, ShouldResemble, MustParse("1.0.0"))
+		})
+	})
+}
+
+func TestIncPrerelease(t *testing.T) {
+	Convey("IncPrerelease works with…", t, FailureContinues, func() {
+		for _, tc := range [][3]string{
+			{"1.2.3", "rc", "1.2.4-rc1"},
+			{"1.2.3.4", "alpha", "1.2.4-alpha1"},
+			{"1.2.3-p1", "rc", "1.2.4-rc1"},
+			{"1.2.4-rc1", "rc", "1.2.4-rc2"},
+			{"1.2.4-rc", "rc", "1.2.4-rc1"},
+			{"1.2.4-rc1.5", "rc", "1.2.4-rc2"},
+			{"1.2.4-beta3", "rc", "1.2.4-rc1"},
+			{"1.2.4-rc2", "beta", "1.2.5-beta1"},
+			{"1.2.4-rc1-p2", "rc", "1.2.4-rc2"},
+			{"1.2.4-rc1+build7", "rc", "1.2.4-rc2"},
+			{"1.0.0-SNAPSHOT", "rc", "1.0.0-rc1"},
+			{"1.0.0-x.7", "rc", "1.0.1-rc1"},
+		} {
+			Convey(tc[0]+" and "+tc[1], func() {
+				v, err := newVocabularyVersion([]byte(tc[0]))
+				So(err, ShouldBeNil)
+				next, err := v.IncPrerelease(tc[1])
+				So(err, ShouldBeNil)
+				So(next.String(), ShouldEqual, tc[2])
+				So(Compare(&next, &v), ShouldEqual, 1)
+			})
+		}
+	})
+
+	Convey("IncPrerelease rejects types that are no pre-release", t, func() {
+		v := MustParse("1.2.3")
+		for _, typ := range []string{"", "p", "r", "gamma", "RC"} {
+			next, err := v.IncPrerelease(typ)
+			So(err, ShouldEqual, errInvalidPreReleaseType)
+			So(next, ShouldResemble, v)
+		}
+	})
+}
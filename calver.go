@@ -0,0 +1,372 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors that are thrown by a CalVer.
+const (
+	errInvalidCalVerFormat  InvalidStringValue = "Given string is no CalVer format"
+	errInvalidCalVerVersion InvalidStringValue = "Given string does not match the CalVer format"
+	errCalVerBeforeVersion  InvalidStringValue = "The date is before that of the Version"
+	errCalVerSamePeriod     InvalidStringValue = "The CalVer format has no counter to tell releases of the same date apart"
+)
+
+// calverSegment is one of the dot-separated parts of a CalVer format.
+type calverSegment int
+
+const (
+	calverFullYear    calverSegment = iota // YYYY: 2006, 2016
+	calverShortYear                        // YY: 6, 16, 106
+	calverPaddedYear                       // 0Y: 06, 16, 106
+	calverMonth                            // MM: 1, 12
+	calverPaddedMonth                      // 0M: 01, 12
+	calverWeek                             // WW: 1, 52
+	calverPaddedWeek                       // 0W: 01, 52
+	calverDay                              // DD: 1, 31
+	calverPaddedDay                        // 0D: 01, 31
+	calverMajor
+	calverMinor
+	calverMicro
+)
+
+var calverSegments = map[string]calverSegment{
+	"YYYY":  calverFullYear,
+	"YY":    calverShortYear,
+	"0Y":    calverPaddedYear,
+	"MM":    calverMonth,
+	"0M":    calverPaddedMonth,
+	"WW":    calverWeek,
+	"0W":    calverPaddedWeek,
+	"DD":    calverDay,
+	"0D":    calverPaddedDay,
+	"MAJOR": calverMajor,
+	"MINOR": calverMinor,
+	"MICRO": calverMicro,
+}
+
+// kind is the segment regardless of its notation, such as calverMonth for "0M".
+func (s calverSegment) kind() calverSegment {
+	switch s {
+	case calverShortYear, calverPaddedYear:
+		return calverFullYear
+	case calverPaddedMonth:
+		return calverMonth
+	case calverPaddedWeek:
+		return calverWeek
+	case calverPaddedDay:
+		return calverDay
+	}
+	return s
+}
+
+func (s calverSegment) isPadded() bool {
+	return s == calverPaddedYear || s == calverPaddedMonth || s == calverPaddedWeek || s == calverPaddedDay
+}
+
+func (s calverSegment) isCounter() bool {
+	return s >= calverMajor
+}
+
+// CalVer is a scheme of calendar versioning, such as "YYYY.MM.DD" or "YY.0M.MICRO",
+// whose Versions have dates in their columns.
+//
+// The week is that of ISO 8601, and with it the year is the one the week belongs to.
+// Short years count from 2000, hence 2006 is "6" as "YY", and "06" as "0Y".
+type CalVer struct {
+	format   string
+	segments []calverSegment
+}
+
+// NewCalVer reads a CalVer format, which consists of up to four segments separated by dots:
+// the year as YYYY, YY, or 0Y; the month as MM or 0M; the week as WW or 0W; the day as DD or 0D;
+// and counters MAJOR, MINOR, and MICRO. Those that start with '0' are zero-padded.
+//
+// A format with a month, week, or day must have a year. A day needs a month, and
+// a week excludes both. No segment can repeat, such as in "YYYY.0Y".
+func NewCalVer(format string) (CalVer, error) {
+	s := CalVer{format: format}
+	str := []byte(format)
+	seen := make(map[calverSegment]bool, 4)
+	var offset int
+	for _, name := range strings.Split(format, ".") {
+		segment, known := calverSegments[name]
+		switch {
+		case !known:
+			return CalVer{}, newParseError(errInvalidCalVerFormat, str, offset, "YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D, MAJOR, MINOR, or MICRO")
+		case seen[segment.kind()]:
+			return CalVer{}, newParseError(errInvalidCalVerFormat, str, offset, "no segment that is already there")
+		case len(s.segments) >= 4:
+			return CalVer{}, newParseError(errTooManyColumns, str, offset-1, "at most four segments")
+		}
+		seen[segment.kind()] = true
+		s.segments = append(s.segments, segment)
+		offset += len(name) + 1
+	}
+
+	switch {
+	case (seen[calverMonth] || seen[calverWeek] || seen[calverDay]) && !seen[calverFullYear]:
+		return CalVer{}, newParseError(errInvalidCalVerFormat, str, len(str), "a year with the month, week, or day")
+	case seen[calverDay] && !seen[calverMonth]:
+		return CalVer{}, newParseError(errInvalidCalVerFormat, str, len(str), "a month with the day")
+	case seen[calverWeek] && seen[calverMonth]:
+		return CalVer{}, newParseError(errInvalidCalVerFormat, str, len(str), "either a week or month")
+	}
+	return s, nil
+}
+
+// String returns the format of the CalVer, such as "YY.0M.MICRO".
+func (s CalVer) String() string {
+	return s.format
+}
+
+// NewVersion reads a Version as NewVersion does, and checks that it matches the format:
+// That every date segment is in the Version, zero-padded only where the format has it,
+// and that it is a valid date. Trailing counters can be left out, such as in "22.04"
+// for "YY.0M.MICRO", and are zero then.
+func (s CalVer) NewVersion(str []byte) (Version, error) {
+	v, err := NewVersion(str)
+	if err != nil {
+		return v, err
+	}
+
+	idx := 0
+	if len(str) > 1 && str[0] == 'v' {
+		idx++
+	}
+	offsets := make([]int, len(s.segments))
+	for i, segment := range s.segments {
+		offsets[i] = idx
+		n := countDigits(str[idx:])
+		switch {
+		case n == 0 && segment.isCounter() && s.hasOnlyCounters(i):
+			continue
+		case n == 0:
+			return v, newParseError(errInvalidCalVerVersion, str, idx, "a digit")
+		case segment.isPadded() && n < 2:
+			return v, newParseError(errInvalidCalVerVersion, str, idx, "two digits")
+		case segment.isPadded() && n > 2 && segment != calverPaddedYear:
+			return v, newParseError(errInvalidCalVerVersion, str, idx+2, "at most two digits")
+		case !segment.isPadded() && n > 1 && str[idx] == '0':
+			return v, newParseError(errInvalidCalVerVersion, str, idx, "a number without leading zeroes")
+		}
+		idx += n
+		if i+1 < len(s.segments) && idx < len(str) && str[idx] == '.' {
+			idx++
+		}
+	}
+	if idx < len(str) && str[idx] == '.' {
+		return v, newParseError(errInvalidCalVerVersion, str, idx, "no further column")
+	}
+	if i, expected := s.invalidSegment(v); i >= 0 {
+		return v, newParseError(errInvalidCalVerVersion, str, offsets[i], expected)
+	}
+	return v, nil
+}
+
+// Parse is NewVersion for strings.
+func (s CalVer) Parse(str string) (Version, error) {
+	return s.NewVersion([]byte(str))
+}
+
+// hasOnlyCounters is true if the segments from the given one on are counters.
+func (s CalVer) hasOnlyCounters(from int) bool {
+	for _, segment := range s.segments[from:] {
+		if !segment.isCounter() {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidSegment returns the index of the first segment whose month, week, or day is out of range,
+// and what had been expected instead; or -1 if there is none.
+func (s CalVer) invalidSegment(v Version) (int, string) {
+	year, month := s.year(v), 1
+	for i, segment := range s.segments {
+		switch n := int(v.version[i]); segment.kind() {
+		case calverMonth:
+			month = n
+			if n < 1 || n > 12 {
+				return i, "a month from 1 to 12"
+			}
+		case calverWeek:
+			if weeks := isoWeeksIn(year); n < 1 || n > weeks {
+				return i, "a week from 1 to " + strconv.Itoa(weeks)
+			}
+		}
+	}
+	for i, segment := range s.segments {
+		if segment.kind() != calverDay {
+			continue
+		}
+		if days := daysIn(year, month); v.version[i] < 1 || int(v.version[i]) > days {
+			return i, "a day from 1 to " + strconv.Itoa(days)
+		}
+	}
+	return -1, ""
+}
+
+// year returns the full year of the Version, or 0 if the format has none.
+func (s CalVer) year(v Version) int {
+	for i, segment := range s.segments {
+		switch segment {
+		case calverFullYear:
+			return int(v.version[i])
+		case calverShortYear, calverPaddedYear:
+			return 2000 + int(v.version[i])
+		}
+	}
+	return 0
+}
+
+// isoWeeksIn returns the number of weeks, 52 or 53, of the year by ISO 8601.
+func isoWeeksIn(year int) int {
+	_, weeks := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return weeks
+}
+
+// daysIn returns the number of days of the month.
+func daysIn(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Format returns the Version as the CalVer writes it, with zero-padding where the format has it,
+// and every segment, such as "22.04.0" for 22.4 and "YY.0M.MICRO".
+// Any pre-release and build follow as Version's String has them.
+func (s CalVer) Format(v Version) string {
+	target := make([]byte, 0, 16)
+	for i, segment := range s.segments {
+		if i > 0 {
+			target = append(target, '.')
+		}
+		n := v.version[i]
+		if segment.isPadded() && n < 10 {
+			target = append(target, '0')
+		}
+		target = strconv.AppendInt(target, int64(n), 10)
+	}
+	if str := v.String(); strings.ContainsAny(str, "-+") {
+		target = append(target, str[strings.IndexAny(str, "-+"):]...)
+	}
+	return string(target)
+}
+
+// Time returns the start of the date of the Version in UTC, which is the first day
+// of the month or year if the format has no day or month, and the Monday of a week.
+//
+// The Version must be one of this CalVer, and the format must have a year.
+func (s CalVer) Time(v Version) (time.Time, error) {
+	year, month, day, week := s.year(v), 1, 1, 0
+	if year == 0 {
+		return time.Time{}, errInvalidCalVerFormat
+	}
+	if i, _ := s.invalidSegment(v); i >= 0 {
+		return time.Time{}, errInvalidCalVerVersion
+	}
+	for i, segment := range s.segments {
+		switch segment.kind() {
+		case calverMonth:
+			month = int(v.version[i])
+		case calverDay:
+			day = int(v.version[i])
+		case calverWeek:
+			week = int(v.version[i])
+		}
+	}
+	if week > 0 {
+		// January 4th is always in the first week.
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7)
+		return monday.AddDate(0, 0, 7*(week-1)), nil
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// FromTime returns the first Version of the date, with any counters zero.
+//
+// The format must have a year, which must not be before 2000 with a short one.
+func (s CalVer) FromTime(t time.Time) (Version, error) {
+	var v Version
+	year := t.Year()
+	_, week := t.ISOWeek()
+	for _, segment := range s.segments {
+		if segment.kind() == calverWeek {
+			year, week = t.ISOWeek()
+		}
+	}
+
+	for i, segment := range s.segments {
+		switch segment.kind() {
+		case calverFullYear:
+			if segment != calverFullYear {
+				if year < 2000 {
+					return Version{}, errInvalidCalVerVersion
+				}
+				v.version[i] = int32(year - 2000)
+			} else {
+				v.version[i] = int32(year)
+			}
+		case calverMonth:
+			v.version[i] = int32(t.Month())
+		case calverWeek:
+			v.version[i] = int32(week)
+		case calverDay:
+			v.version[i] = int32(t.Day())
+		}
+	}
+	if s.year(v) == 0 {
+		return Version{}, errInvalidCalVerFormat
+	}
+	return v, nil
+}
+
+// Next returns the Version of the next release on the given date, after v.
+//
+// On a later date, such as in a later month for "YY.0M.MICRO", that is the first Version
+// of the date, which keeps any counters in front of the date, such as MAJOR in "MAJOR.YYYY".
+// On the same date the last counter is incremented, unless v is a pre-release,
+// which is released then. Dates before that of v are an error,
+// as is a second release on the same date without counter.
+func (s CalVer) Next(v Version, t time.Time) (Version, error) {
+	next, err := s.FromTime(t)
+	if err != nil {
+		return Version{}, err
+	}
+	last, err := s.Time(v)
+	if err != nil {
+		return Version{}, err
+	}
+	current, _ := s.Time(next)
+
+	switch {
+	case current.Before(last):
+		return Version{}, errCalVerBeforeVersion
+	case current.After(last):
+		for i, segment := range s.segments {
+			if !segment.isCounter() {
+				break
+			}
+			next.version[i] = v.version[i]
+		}
+		return next, nil
+	}
+
+	copy(next.version[:len(s.segments)], v.version[:len(s.segments)])
+	if v.IsAPreRelease() {
+		return next, nil
+	}
+	for i := len(s.segments) - 1; i >= 0; i-- {
+		if s.segments[i].isCounter() {
+			next.version[i]++
+			return next, nil
+		}
+	}
+	return Version{}, errCalVerSamePeriod
+}
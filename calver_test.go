@@ -0,0 +1,194 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustCalVer(format string) CalVer {
+	s, err := NewCalVer(format)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNewCalVer(t *testing.T) {
+	Convey("NewCalVer reads the formats…", t, FailureContinues, func() {
+		for _, format := range []string{
+			"YYYY.MM.DD", "YY.0M.MICRO", "YYYY.WW", "YY.MINOR", "MAJOR.YYYY.MM", "YYYY.0M.0D.MICRO", "0Y.0W", "MAJOR.MINOR",
+		} {
+			s, err := NewCalVer(format)
+			So(err, ShouldBeNil)
+			So(s.String(), ShouldEqual, format)
+		}
+	})
+
+	Convey("NewCalVer rejects, and tells where…", t, FailureContinues, func() {
+		for format, offset := range map[string]int{
+			"YYYY-MM":                0,
+			"YYYY.mm":                5,
+			"YYYY.0Y":                5,
+			"YYYY.MM..DD":            8,
+			"YYYY.MM.DD.MICRO.MINOR": 16,
+			"MM.DD":                  5,
+			"YYYY.DD":                7,
+			"YYYY.MM.WW":             10,
+		} {
+			Convey(format, func() {
+				_, err := NewCalVer(format)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
+
+func TestCalVerVersions(t *testing.T) {
+	Convey("CalVer reads, and writes…", t, FailureContinues, func() {
+		for format, versions := range map[string]map[string]string{
+			"YY.0M.MICRO": {"22.04": "22.04.0", "22.04.1": "22.04.1", "6.10.2": "6.10.2", "v22.10": "22.10.0"},
+			"YYYY.MM.DD":  {"2024.10.17": "2024.10.17", "2024.2.29": "2024.2.29", "2024.10.17-rc1": "2024.10.17-rc1"},
+			"YYYY.0W":     {"2020.53": "2020.53", "2021.01": "2021.01"},
+			"0Y.MINOR":    {"06.2": "06.2", "106.0": "106.0"},
+			"YY.MINOR":    {"24.0": "24.0", "24": "24.0"},
+		} {
+			s := mustCalVer(format)
+			for str, written := range versions {
+				Convey(format+" and "+str, func() {
+					v, err := s.Parse(str)
+					So(err, ShouldBeNil)
+					So(s.Format(v), ShouldEqual, written)
+				})
+			}
+		}
+	})
+
+	Convey("CalVer rejects, and tells where…", t, FailureContinues, func() {
+		for format, versions := range map[string]map[string]int{
+			"YY.0M.MICRO": {"22.4": 3, "22.13": 3, "022.04": 0, "22.004": 5},
+			"YYYY.MM.DD":  {"2024.02.28": 5, "2024.2.30": 7, "2023.2.29": 7, "2024.10": 7, "2024.10.17.1": 10, "2024.0.1": 5},
+			"YYYY.0W":     {"2021.53": 5, "2021.00": 5},
+		} {
+			s := mustCalVer(format)
+			for str, offset := range versions {
+				Convey(format+" and "+str, func() {
+					_, err := s.Parse(str)
+					So(errors.Is(err, errInvalidCalVerVersion), ShouldBeTrue)
+					var e *ParseError
+					So(errors.As(err, &e), ShouldBeTrue)
+					So(e.Offset, ShouldEqual, offset)
+				})
+			}
+		}
+	})
+}
+
+func TestCalVerTime(t *testing.T) {
+	Convey("CalVer converts to time.Time", t, FailureContinues, func() {
+		for format, versions := range map[string]map[string]time.Time{
+			"YY.0M.MICRO":   {"22.04.1": date(2022, time.April, 1)},
+			"YYYY.MM.DD":    {"2024.10.17": date(2024, time.October, 17)},
+			"YYYY.0W":       {"2024.01": date(2024, time.January, 1), "2021.01": date(2021, time.January, 4), "2020.53": date(2020, time.December, 28)},
+			"MAJOR.YYYY":    {"3.2023": date(2023, time.January, 1)},
+			"0Y.MINOR":      {"06.2": date(2006, time.January, 1)},
+			"YYYY.0M.MICRO": {"2024.12.3": date(2024, time.December, 1)},
+		} {
+			s := mustCalVer(format)
+			for str, expected := range versions {
+				Convey(format+" and "+str, func() {
+					v, err := s.Parse(str)
+					So(err, ShouldBeNil)
+					got, err := s.Time(v)
+					So(err, ShouldBeNil)
+					So(got, ShouldEqual, expected)
+				})
+			}
+		}
+
+		_, err := mustCalVer("MAJOR.MINOR").Time(MustParse("1.2"))
+		So(err, ShouldEqual, errInvalidCalVerFormat)
+		_, err = mustCalVer("YYYY.MM").Time(MustParse("2024.13"))
+		So(err, ShouldEqual, errInvalidCalVerVersion)
+	})
+
+	Convey("CalVer converts from time.Time", t, FailureContinues, func() {
+		for format, expected := range map[string]string{
+			"YY.0M.MICRO": "22.04.0",
+			"YYYY.MM.DD":  "2022.4.21",
+			"YYYY.0W":     "2022.16",
+			"MAJOR.YYYY":  "0.2022",
+		} {
+			s := mustCalVer(format)
+			v, err := s.FromTime(date(2022, time.April, 21))
+			So(err, ShouldBeNil)
+			So(s.Format(v), ShouldEqual, expected)
+		}
+
+		s := mustCalVer("YYYY.0W")
+		v, _ := s.FromTime(date(2024, time.December, 30))
+		So(s.Format(v), ShouldEqual, "2025.01")
+
+		_, err := mustCalVer("YY.MINOR").FromTime(date(1999, time.December, 31))
+		So(err, ShouldEqual, errInvalidCalVerVersion)
+		_, err = mustCalVer("MAJOR.MINOR").FromTime(date(2024, time.December, 31))
+		So(err, ShouldEqual, errInvalidCalVerFormat)
+	})
+}
+
+func TestCalVerNext(t *testing.T) {
+	Convey("Next releases…", t, FailureContinues, func() {
+		for _, tc := range []struct {
+			format, version string
+			on              time.Time
+			expected        string
+		}{
+			{"YY.0M.MICRO", "22.04.3", date(2022, time.April, 30), "22.04.4"},
+			{"YY.0M.MICRO", "22.04.3", date(2022, time.May, 1), "22.05.0"},
+			{"YY.0M.MICRO", "22.04", date(2022, time.April, 2), "22.04.1"},
+			{"YYYY.MM.DD", "2024.10.17", date(2024, time.October, 18), "2024.10.18"},
+			{"YYYY.MM.DD", "2024.10.17-rc1", date(2024, time.October, 17), "2024.10.17"},
+			{"YY.MINOR", "24.0", date(2024, time.June, 1), "24.1"},
+			{"YY.MINOR", "24.3", date(2025, time.January, 1), "25.0"},
+			{"MAJOR.YYYY", "3.2023", date(2024, time.March, 1), "3.2024"},
+			{"YYYY.0W.MICRO", "2024.52.1", date(2024, time.December, 29), "2024.52.2"},
+			{"YYYY.0W.MICRO", "2024.52.1", date(2024, time.December, 30), "2025.01.0"},
+		} {
+			Convey(tc.format+" after "+tc.version+" on "+tc.on.Format("2006-01-02"), func() {
+				s := mustCalVer(tc.format)
+				v, err := s.Parse(tc.version)
+				So(err, ShouldBeNil)
+				next, err := s.Next(v, tc.on)
+				So(err, ShouldBeNil)
+				So(s.Format(next), ShouldEqual, tc.expected)
+			})
+		}
+	})
+
+	Convey("Next rejects…", t, FailureContinues, func() {
+		Convey("dates before the Version", func() {
+			s := mustCalVer("YY.0M.MICRO")
+			v, _ := s.Parse("22.04.3")
+			_, err := s.Next(v, date(2022, time.March, 31))
+			So(err, ShouldEqual, errCalVerBeforeVersion)
+		})
+		Convey("a second release on the same date without counter", func() {
+			s := mustCalVer("YYYY.MM.DD")
+			v, _ := s.Parse("2024.10.17")
+			_, err := s.Next(v, date(2024, time.October, 17))
+			So(err, ShouldEqual, errCalVerSamePeriod)
+		})
+	})
+}
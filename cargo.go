@@ -0,0 +1,176 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"unicode"
+)
+
+const errInvalidCargoRange InvalidStringValue = "Given string does not resemble a Cargo version requirement"
+
+// cargoOperators in the order they are tried, the longer before any shorter one.
+var cargoOperators = [...]string{">=", "<=", ">", "<", "=", "~", "^"}
+
+// NewCargoRange reads a version requirement as Cargo, the package manager of Rust, does,
+// such as "1.2", ">=1.2, <1.5", "~1", "1.2.*", or "=0.3.1".
+//
+// All comparators, which are separated by commas, must match.
+// Unlike in NewRange a version without operator is read as if it had a caret, "^",
+// and a partial version such as "1.2" is filled up with zeroes.
+// The caret allows changes that don't modify the leftmost non-zero column:
+//
+//   ^1.2.3  is  >=1.2.3, <2.0.0
+//   ^0.2.3  is  >=0.2.3, <0.3.0
+//   ^0.0.3  is  >=0.0.3, <0.0.4
+//   ^0.0    is  >=0.0.0, <0.1.0
+//   ^0      is  >=0.0.0, <1.0.0
+//
+// The tilde allows patch-level changes, or minor-level changes if only the major is given.
+// Wildcards such as in "1.2.*" and "1.*" stand for "=1.2" and "=1",
+// which include all Versions with that prefix. A sole "*" contains everything.
+//
+// As Cargo does, IsSatisfiedBy accepts pre-releases only with a comparator of the same
+// major, minor, and patch, which has a pre-release, too: "^1.2.3-alpha" matches 1.2.3-beta,
+// but not 1.2.4-beta. Unlike Cargo, and like any Range does, an exclusive upper boundary
+// such as "<2.0.0" excludes the pre-releases of 2.0.0 from Contains as well.
+func NewCargoRange(str []byte) (Range, error) {
+	if len(bytes.TrimSpace(str)) == 0 {
+		return Range{}, newParseError(errInvalidCargoRange, str, len(str), "a comparator")
+	}
+	var r Range
+	var offset int
+	for _, comparator := range bytes.Split(str, []byte(",")) {
+		c, err := newCargoComparator(comparator)
+		if err != nil {
+			return Range{}, atOffset(err, str, offset)
+		}
+		r, _ = r.Intersect(c)
+		offset += len(comparator) + 1
+	}
+	return r, nil
+}
+
+// ParseCargoRange is NewCargoRange for strings.
+func ParseCargoRange(str string) (Range, error) {
+	return NewCargoRange([]byte(str))
+}
+
+// newCargoComparator reads an optional operator, followed by a Version of which up to three columns are given.
+func newCargoComparator(str []byte) (Range, error) {
+	idx := skipSpace(str, 0)
+	var operator string
+	for _, op := range cargoOperators {
+		if bytes.HasPrefix(str[idx:], []byte(op)) {
+			operator = op
+			break
+		}
+	}
+	idx = skipSpace(str, idx+len(operator))
+	end := idx + len(bytes.TrimRightFunc(str[idx:], unicode.IsSpace))
+
+	// Any column after a wildcard must be a wildcard, too.
+	var columns int
+	var wildcard bool
+	i, versionEnd := idx, idx
+	for column := 0; column < 3; column++ {
+		if column > 0 {
+			if i >= end || str[i] != '.' {
+				break
+			}
+			i++
+		}
+		if i < end && (str[i] == '*' || str[i]|0x20 == 'x') {
+			wildcard = true
+			i++
+			continue
+		}
+		n := countDigits(str[i:end])
+		switch {
+		case wildcard:
+			return Range{}, newParseError(errInvalidCargoRange, str, i, "a wildcard")
+		case n == 0:
+			return Range{}, newParseError(errInvalidCargoRange, str, i, "a digit or wildcard")
+		case n > 1 && str[i] == '0':
+			return Range{}, newParseError(errInvalidCargoRange, str, i, "a number without leading zeroes")
+		}
+		columns++
+		i += n
+		versionEnd = i
+	}
+	switch {
+	case i < end && columns < 3:
+		return Range{}, newParseError(errInvalidCargoRange, str, i, "'.', ',', or the end")
+	case i < end && str[i] != '-' && str[i] != '+':
+		return Range{}, newParseError(errInvalidCargoRange, str, i, "a pre-release, ',', or the end")
+	case columns == 0 && operator != "":
+		return Range{}, newParseError(errInvalidCargoRange, str, idx, "a digit")
+	case columns == 0:
+		return Range{}, nil
+	}
+
+	if columns == 3 {
+		versionEnd = end
+	}
+	v, err := NewVersionWithIdentifiers(str[idx:versionEnd])
+	if err != nil {
+		return Range{}, atOffset(err, str, idx)
+	}
+	v.build, v.buildMetadata = 0, "" // Cargo ignores build metadata in requirements.
+
+	if operator == "" {
+		operator = "^"
+		if wildcard {
+			operator = "="
+		}
+	}
+	if operator == "~" && columns < 3 {
+		operator = "="
+	}
+	if operator == "^" {
+		// The leftmost non-zero column, or the last given one, must not change.
+		fixed := columns
+		for i := 0; i < columns; i++ {
+			if v.version[i] != 0 {
+				fixed = i + 1
+				break
+			}
+		}
+		return Range{lower: v, hasLower: true, equalsLower: true, upper: nextPrefix(v, fixed), hasUpper: true}, nil
+	}
+
+	switch operator {
+	case "=":
+		if columns == 3 {
+			return Range{lower: v, hasLower: true, equalsLower: true, upper: v, hasUpper: true, equalsUpper: true}, nil
+		}
+		return Range{lower: v, hasLower: true, equalsLower: true, upper: nextPrefix(v, columns), hasUpper: true}, nil
+	case ">":
+		if columns == 3 {
+			return Range{lower: v, hasLower: true}, nil
+		}
+		return Range{lower: nextPrefix(v, columns), hasLower: true, equalsLower: true}, nil
+	case ">=":
+		return Range{lower: v, hasLower: true, equalsLower: true}, nil
+	case "<":
+		return Range{upper: v, hasUpper: true}, nil
+	case "<=":
+		if columns == 3 {
+			return Range{upper: v, hasUpper: true, equalsUpper: true}, nil
+		}
+		return Range{upper: nextPrefix(v, columns), hasUpper: true}, nil
+	}
+	// "~" with three columns.
+	return Range{lower: v, hasLower: true, equalsLower: true, upper: nextPrefix(v, 2), hasUpper: true}, nil
+}
+
+// nextPrefix returns the lowest release after all Versions that share the first columns with v,
+// such as 1.3.0 for 1.2.3 and two columns.
+func nextPrefix(v Version, columns int) Version {
+	var next Version
+	copy(next.version[:columns], v.version[:columns])
+	next.version[columns-1]++
+	return next
+}
@@ -0,0 +1,124 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewCargoRange(t *testing.T) {
+	// Mostly from the tests of Rust's semver crate, which Cargo uses.
+	Convey("Cargo requirements are satisfied by…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"1.0.0":  {"1.0.0": true, "1.0.1": true, "0.9.9": false, "0.10.0": false, "0.1.0": false, "1.0.0-pre": false, "0.0.1": false},
+			"=1.0.0": {"1.0.0": true, "1.0.1": false, "0.9.9": false, "1.0.0-pre": false},
+			"=0.1.0-beta2.a": {
+				"0.1.0-beta2.a": true, "0.9.1": false, "0.1.0": false, "0.1.1-beta2.a": false, "0.1.0-beta2": false,
+			},
+			"=0.1.0+meta": {"0.1.0": true, "0.1.0+meta": true, "0.1.0+any": true},
+			"=1.2":        {"1.2.0": true, "1.2.9": true, "1.3.0": false, "1.1.9": false},
+			"=1":          {"1.0.0": true, "1.9.9": true, "2.0.0": false},
+			">= 1.0.0":    {"1.0.0": true, "2.0.0": true, "0.1.0": false, "0.0.1": false, "1.0.0-pre": false, "2.0.0-pre": false},
+			">= 2.1.0-alpha2": {
+				"2.1.0-alpha2": true, "2.1.0-alpha3": true, "2.1.0": true, "3.0.0": true,
+				"2.0.0": false, "2.1.0-alpha1": false, "2.0.0-alpha2": false, "3.0.0-alpha2": false,
+			},
+			">1.2.3":  {"1.2.3": false, "1.2.4": true},
+			">1.2":    {"1.2.9": false, "1.3.0": true},
+			">1":      {"1.9.9": false, "2.0.0": true},
+			">=1.2":   {"1.1.9": false, "1.2.0": true},
+			"< 1.0.0": {"0.1.0": true, "0.0.1": true, "1.0.0": false, "1.0.0-beta": false, "1.0.1": false, "0.9.9-alpha": false},
+			"<= 2.1.0-alpha2": {
+				"2.1.0-alpha2": true, "2.1.0-alpha1": true, "2.0.0": true, "1.0.0": true,
+				"2.1.0": false, "2.2.0-alpha1": false, "2.0.0-alpha2": false, "1.0.0-alpha2": false,
+			},
+			"<1.2":                 {"1.1.9": true, "1.2.0": false},
+			"<=1.2":                {"1.2.9": true, "1.3.0": false},
+			"<=1":                  {"1.9.9": true, "2.0.0": false},
+			">=1.2, <1.5":          {"1.1.0": false, "1.2.0": true, "1.4.9": true, "1.5.0": false},
+			" >= 1.2 ,  < 1.5 ":    {"1.3.0": true, "1.5.0": false},
+			">=0.5.1-alpha3, <0.6": {"0.5.1-alpha3": true, "0.5.1-beta": true, "0.5.2-alpha3": false, "0.5.9": true, "0.6.0": false},
+			">2, <1":               {"1.5.0": false, "2.5.0": false},
+			"~1.0.0":               {"1.0.0": true, "1.0.1": true, "0.1.0": false, "1.1.0": false, "0.0.1": false},
+			"~1.0":                 {"1.0.2": true, "1.0.0": true, "1.1.0": false},
+			"~1":                   {"1.0.0": true, "1.2.3": true, "2.0.0": false},
+			"~1.2.3-beta":          {"1.2.3-beta": true, "1.2.3": true, "1.2.4": true, "1.3.0": false, "1.2.4-beta": false},
+			"^1":                   {"1.1.2": true, "1.1.0": true, "1.2.1": true, "1.0.1": true, "0.9.1": false, "2.9.0": false, "0.1.4": false},
+			"^1.1": {
+				"1.1.2": true, "1.1.0": true, "1.2.1": true, "0.9.1": false, "2.9.0": false, "1.0.1": false, "0.1.4": false,
+			},
+			"^1.1.2": {
+				"1.1.2": true, "1.1.4": true, "1.2.1": true,
+				"0.9.1": false, "2.9.0": false, "1.1.1": false, "0.0.1": false, "1.1.2-alpha1": false,
+			},
+			"^0.1.2": {"0.1.2": true, "0.1.4": true, "0.9.1": false, "2.9.0": false, "1.1.1": false, "0.1.1": false},
+			"^0.5.1-alpha3": {
+				"0.5.1-alpha3": true, "0.5.1-alpha4": true, "0.5.1-beta": true, "0.5.1": true, "0.5.5": true,
+				"0.5.1-alpha1": false, "0.5.2-alpha3": false, "0.5.5-pre": false, "0.5.0-pre": false, "0.6.0": false,
+			},
+			"^0.0.2": {"0.0.2": true, "0.9.1": false, "2.9.0": false, "1.1.1": false, "0.0.1": false, "0.0.3": false},
+			"^0.0":   {"0.0.2": true, "0.0.0": true, "0.9.1": false, "2.9.0": false, "1.1.1": false, "0.1.4": false},
+			"^0":     {"0.9.1": true, "0.0.2": true, "0.0.0": true, "2.9.0": false, "1.1.1": false},
+			"^1.4.2-beta.5": {
+				"1.4.2": true, "1.4.3": true, "1.4.2-beta.5": true, "1.4.2-beta.6": true, "1.4.2-c": true,
+				"0.9.9": false, "2.0.0": false, "1.4.2-alpha": false, "1.4.2-beta.4": false, "1.4.3-beta.5": false,
+			},
+			"*":            {"0.9.1": true, "2.9.0": true, "0.0.9": true, "1.0.1": true, "1.1.1": true, "1.0.0-pre": false},
+			"1.*":          {"1.2.0": true, "1.2.1": true, "1.1.1": true, "1.3.0": true, "0.0.9": false, "2.0.0": false},
+			"1.2.*":        {"1.2.0": true, "1.2.2": true, "1.2.4": true, "1.9.0": false, "1.0.9": false, "2.0.1": false, "0.1.3": false},
+			"1.x.X":        {"1.9.0": true, "2.0.0": false},
+			"1.2.3+build5": {"1.2.3": true, "1.9.9": true, "2.0.0": false},
+		} {
+			r, err := ParseCargoRange(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					v, err := NewVersionWithIdentifiers([]byte(version))
+					So(err, ShouldBeNil)
+					So(r.IsSatisfiedBy(v), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("Cargo requirements are Ranges", t, func() {
+		r, _ := ParseCargoRange(">=1.2, <1.5")
+		expected, _ := NewRange([]byte(">=1.2.0 <1.5.0"))
+		So(r, ShouldResemble, expected)
+		r, _ = ParseCargoRange(">2, <1")
+		So(r.IsEmpty(), ShouldBeTrue)
+	})
+
+	Convey("NewCargoRange rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":            0,
+			"  ":          2,
+			">= >= 0.0.2": 3,
+			">== 0.0.2":   2,
+			"a.0.0":       0,
+			"1.0.0 1.1":   5,
+			"1.*.3":       4,
+			"01.0":        0,
+			"1.2.3.4":     5,
+			"1.2-rc1":     3,
+			">=1.0, ":     7,
+			">=*":         2,
+			"1.0, ~":      6,
+			"v1.0":        0,
+		} {
+			Convey(str, func() {
+				_, err := ParseCargoRange(str)
+				So(errors.Is(err, errInvalidCargoRange), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Input, ShouldEqual, str)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
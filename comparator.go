@@ -0,0 +1,43 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+// Comparator orders the versions of one ecosystem, given as strings.
+// Use it where the ecosystem is known only at runtime, such as when scanning images.
+type Comparator interface {
+	// Compare returns the signum of the difference between a and b, in the order of the ecosystem,
+	// or an error if either is not a version of it.
+	Compare(a, b string) (int, error)
+}
+
+// ComparatorFunc is a function that implements Comparator.
+type ComparatorFunc func(a, b string) (int, error)
+
+// Compare implements the Comparator interface.
+func (f ComparatorFunc) Compare(a, b string) (int, error) {
+	return f(a, b)
+}
+
+// ComparatorFor returns the Comparator for the versions of the named ecosystem, or nil if there's none.
+//
+// It reads and orders versions as the Scheme of that name does, see SchemeFor,
+// hence knows those registered by RegisterScheme as well.
+func ComparatorFor(ecosystem string) Comparator {
+	s := SchemeFor(ecosystem)
+	if s == nil {
+		return nil
+	}
+	return ComparatorFunc(func(a, b string) (int, error) {
+		v, err := s.ParseVersion(a)
+		if err != nil {
+			return 0, err
+		}
+		o, err := s.ParseVersion(b)
+		if err != nil {
+			return 0, err
+		}
+		return s.Compare(v, o)
+	})
+}
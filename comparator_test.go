@@ -0,0 +1,76 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestComparatorFor(t *testing.T) {
+	Convey("ComparatorFor picks by ecosystem…", t, FailureContinues, func() {
+		for ecosystem, pair := range map[string][2]string{
+			"semver":   {"1.0.0-alpha.1", "1.0.0"},
+			"npm":      {"1.0.0-alpha.1", "1.0.0-alpha.beta"},
+			"cargo":    {"1.0.0-rc.1", "1.0.0"},
+			"composer": {"1.0.0-RC1", "1.0.0-p1"},
+			"alpine":   {"1.0_rc1-r1", "1.0-r0"},
+			"apk":      {"1.36.1-r5", "1.36.1_git20240101-r0"},
+			"arch":     {"1.0a-1", "1.0-1"},
+			"pacman":   {"1.5.b", "1.5.1"},
+			"debian":   {"1.0~rc1-1", "1.0-1"},
+			"deb":      {"1:0.9", "2:0.1"},
+			"rpm":      {"1.0~rc1", "1.0^20210101"},
+			"gentoo":   {"1.0_p1", "1.0a"},
+			"pypi":     {"1.0rc1", "1.0.post1"},
+			"maven":    {"1.0-SNAPSHOT", "1.0"},
+			"go":       {"v0.0.0-20210101120000-abcdef123456", "v0.1.0"},
+			"rubygems": {"1.0.0.rc1", "1.0.0"},
+			"nuget":    {"1.0.0-beta", "1.0.0.1"},
+		} {
+			Convey(ecosystem, func() {
+				c := ComparatorFor(ecosystem)
+				So(c, ShouldNotBeNil)
+				result, err := c.Compare(pair[0], pair[1])
+				So(err, ShouldBeNil)
+				So(result, ShouldEqual, -1)
+				result, err = c.Compare(pair[1], pair[0])
+				So(err, ShouldBeNil)
+				So(result, ShouldEqual, 1)
+				result, err = c.Compare(pair[0], pair[0])
+				So(err, ShouldBeNil)
+				So(result, ShouldEqual, 0)
+			})
+		}
+	})
+
+	Convey("Comparators return errors of the versions", t, func() {
+		_, err := ComparatorFor("alpine").Compare("1.0", "1.0-1")
+		So(err, ShouldNotBeNil)
+		_, err = ComparatorFor("arch").Compare("1.0-a", "1.0")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("ComparatorFor returns nil for unknown ecosystems", t, func() {
+		So(ComparatorFor("cobol"), ShouldBeNil)
+	})
+
+	Convey("ComparatorFor orders as the Scheme of the same name", t, func() {
+		result, err := ComparatorFor("").Compare("1.0.0-rc9", "1.0.0-rc10")
+		So(err, ShouldBeNil)
+		So(result, ShouldEqual, -1)
+		result, err = ComparatorFor("npm").Compare("1.0.0-rc9", "1.0.0-rc10")
+		So(err, ShouldBeNil)
+		So(result, ShouldEqual, 1) // By §11 SemVer.
+
+		RegisterScheme("test-rpm", SchemeFor("rpm"))
+		c := ComparatorFor("test-rpm")
+		So(c, ShouldNotBeNil)
+		result, err = c.Compare("1.0~rc1", "1.0")
+		So(err, ShouldBeNil)
+		So(result, ShouldEqual, -1)
+	})
+}
@@ -0,0 +1,510 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"strings"
+)
+
+const errInvalidComposerConstraint InvalidStringValue = "Given string does not resemble a Composer version constraint"
+
+// Stability is how mature a release is, as Composer tells by its minimum-stability.
+// The least stable is StabilityDev.
+type Stability int
+
+// Stabilities in ascending order.
+const (
+	StabilityDev Stability = iota
+	StabilityAlpha
+	StabilityBeta
+	StabilityRC
+	StabilityStable
+)
+
+var stabilityDesc = [...]string{"dev", "alpha", "beta", "RC", "stable"}
+
+var stabilityValue = map[string]Stability{
+	"dev":    StabilityDev,
+	"alpha":  StabilityAlpha,
+	"beta":   StabilityBeta,
+	"rc":     StabilityRC,
+	"stable": StabilityStable,
+}
+
+// String returns the Stability as Composer writes it, such as "RC" or "dev".
+func (s Stability) String() string {
+	if s < StabilityDev || s > StabilityStable {
+		return ""
+	}
+	return stabilityDesc[s]
+}
+
+// Stability of a Version, which is StabilityStable unless it is a pre-release.
+//
+// Pre-releases beyond the fixed vocabulary are told by their leading letters,
+// such as StabilityRC for "1.0.0-RC.1"; any unknown count as StabilityDev.
+// A "pre" is as stable as a beta.
+func (t Version) Stability() Stability {
+	switch t.version[idxReleaseType] {
+	case alpha:
+		return StabilityAlpha
+	case beta, pre:
+		return StabilityBeta
+	case rc:
+		return StabilityRC
+	case identified:
+	default:
+		return StabilityStable
+	}
+
+	str := strings.ToLower(t.preRelease)
+	n := 0
+	for n < len(str) && isSmallLetter(str[n]) {
+		n++
+	}
+	switch str[:n] {
+	case "a", "alpha":
+		return StabilityAlpha
+	case "b", "beta":
+		return StabilityBeta
+	case "rc":
+		return StabilityRC
+	}
+	return StabilityDev
+}
+
+// composerModifiers are the stability suffixes Composer reads, with the release type each translates to.
+var composerModifiers = map[string]int{
+	"stable": common,
+	"alpha":  alpha,
+	"a":      alpha,
+	"beta":   beta,
+	"b":      beta,
+	"rc":     rc,
+	"patch":  patch,
+	"pl":     patch,
+	"p":      patch,
+}
+
+// ComposerConstraint is a version constraint of Composer, the package manager of PHP,
+// such as "^1.2 | ^2.0" or ">=1.0 <1.1 || >=1.2@beta".
+//
+// It is a RangeSet, and the stability that any flags and pre-releases in it ask for.
+type ComposerConstraint struct {
+	set       RangeSet
+	stability Stability
+	str       string
+}
+
+// NewComposerConstraint reads a version constraint as Composer does.
+//
+// Alternatives are separated by "|" or "||", and within them constraints that must all match
+// by commas or spaces. Each is an optional operator and a version of up to four columns,
+// which is filled up with zeroes. Without operator it is an exact version, or has wildcards:
+//
+//   1.0.*          is  >=1.0.0-0, <1.1.0
+//   ^1.2.3         is  >=1.2.3-0, <2.0.0
+//   ^0.3           is  >=0.3.0-0, <0.4.0
+//   ~1.2.3         is  >=1.2.3-0, <1.3.0
+//   ~1.2           is  >=1.2.0-0, <2.0.0
+//   1.0 - 2.0      is  >=1.0.0-0, <2.1.0
+//   1.0.0 - 2.1.0  is  >=1.0.0-0, <=2.1.0
+//
+// As in Composer lower boundaries without a stability suffix include their pre-releases,
+// written here as the lowest pre-release "-0". An exclusive upper one excludes them.
+// Stability suffixes such as in "1.0-beta2", "1.0-RC", "1.0-stable" or "1.0-dev" are read,
+// as are stability flags such as in "^1.2@beta" or "1.0.*@dev".
+// Like everywhere in a Range "!=1.0" excludes the pre-releases of 1.0 as well.
+//
+// Versions to check against it are best read by NewVersion, which orders "1.0.0-RC10"
+// after "1.0.0-RC9" and takes "1.0.0-p1" for a patch release as Composer does,
+// whereas NewVersionWithIdentifiers reads both as SemVer pre-releases.
+func NewComposerConstraint(str []byte) (ComposerConstraint, error) {
+	trimmed := bytes.TrimSpace(str)
+	c := ComposerConstraint{stability: StabilityStable, str: string(trimmed)}
+	if len(trimmed) == 0 {
+		return ComposerConstraint{}, newParseError(errInvalidComposerConstraint, str, len(str), "a constraint")
+	}
+
+	for start := 0; start <= len(str); {
+		end := start + bytes.IndexByte(str[start:], '|')
+		next := end + 1
+		switch {
+		case end < start:
+			end, next = len(str), len(str)+1
+		case next < len(str) && str[next] == '|':
+			next++
+		}
+
+		set, stability, err := newComposerAlternative(str[start:end])
+		if err != nil {
+			return ComposerConstraint{}, atOffset(err, str, start)
+		}
+		c.set = append(c.set, set...)
+		if stability < c.stability {
+			c.stability = stability
+		}
+		start = next
+	}
+	return c, nil
+}
+
+// ParseComposerConstraint is NewComposerConstraint for strings.
+func ParseComposerConstraint(str string) (ComposerConstraint, error) {
+	return NewComposerConstraint([]byte(str))
+}
+
+// isComposerSeparator is true for what separates constraints that must all match.
+func isComposerSeparator(ch byte) bool {
+	return ch == ',' || ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
+
+// newComposerAlternative reads constraints that must all match into the RangeSet of their intersection.
+func newComposerAlternative(str []byte) (RangeSet, Stability, error) {
+	// Spans of the constraints, with any operator and a version separated by spaces joined.
+	var spans [][2]int
+	for i := 0; i < len(str); {
+		for i < len(str) && isComposerSeparator(str[i]) {
+			i++
+		}
+		if i >= len(str) {
+			break
+		}
+		start := i
+		for i < len(str) && !isComposerSeparator(str[i]) {
+			i++
+		}
+		if n := len(spans); n > 0 && len(bytes.Trim(str[spans[n-1][0]:spans[n-1][1]], "<>=!~^")) == 0 {
+			spans[n-1][1] = i
+			continue
+		}
+		spans = append(spans, [2]int{start, i})
+	}
+	if len(spans) == 0 {
+		return nil, 0, newParseError(errInvalidComposerConstraint, str, len(str), "a constraint")
+	}
+
+	set, stability := RangeSet{Range{}}, StabilityStable
+	for i := 0; i < len(spans); i++ {
+		var ranges RangeSet
+		var s Stability
+		var err error
+		start, end := spans[i][0], spans[i][1]
+		if i+2 < len(spans) && string(str[spans[i+1][0]:spans[i+1][1]]) == "-" {
+			end = spans[i+2][1]
+			ranges, s, err = newComposerHyphenRange(str[start:end], spans[i][1]-start, spans[i+2][0]-start)
+			i += 2
+		} else {
+			ranges, s, err = newComposerComparator(str[start:end])
+		}
+		if err != nil {
+			return nil, 0, atOffset(err, str, start)
+		}
+		if s < stability {
+			stability = s
+		}
+		set = intersectRangeSets(set, ranges)
+	}
+	return set, stability, nil
+}
+
+// intersectRangeSets returns the non-empty intersections of every Range in a with any in b.
+func intersectRangeSets(a, b RangeSet) RangeSet {
+	set := make(RangeSet, 0, len(a))
+	for i := range a {
+		for j := range b {
+			if r, ok := a[i].Intersect(b[j]); ok {
+				set = append(set, r)
+			}
+		}
+	}
+	return set
+}
+
+// composerOperators in the order they are tried, the longer before any shorter one.
+var composerOperators = [...]string{">=", "<=", "<>", "!=", "==", ">", "<", "=", "^", "~"}
+
+// newComposerComparator reads an optional operator and a version, such as "~1.2.3" or ">=1.0@beta".
+func newComposerComparator(str []byte) (RangeSet, Stability, error) {
+	str, flag, err := cutComposerFlag(str)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(bytes.Trim(str, "*xX.")) == 0 && len(str) > 0 && str[0] != '.' && str[len(str)-1] != '.' {
+		return RangeSet{Range{}}, flag, nil
+	}
+
+	var operator string
+	for _, op := range composerOperators {
+		if bytes.HasPrefix(str, []byte(op)) {
+			operator = op
+			break
+		}
+	}
+	idx := skipSpace(str, len(operator))
+	if operator == "~" && idx < len(str) && str[idx] == '>' {
+		return nil, 0, newParseError(errInvalidComposerConstraint, str, idx, "a version, as \"~>\" is not Composer's")
+	}
+	b, err := newComposerBound(str[idx:])
+	if err != nil {
+		return nil, 0, atOffset(err, str, idx)
+	}
+	if b.wildcard && operator != "" {
+		return nil, 0, newParseError(errInvalidComposerConstraint, str, 0, "no operator before a wildcard")
+	}
+	stability := b.v.Stability()
+	if flag < stability {
+		stability = flag
+	}
+
+	lower := b.lowest()
+	switch operator {
+	case ">", ">=", "<", "<=", "!=", "<>":
+		if !b.hasModifier && flag != StabilityStable {
+			// The flag becomes the stability suffix, such as in ">=1.0@beta" for ">=1.0-beta".
+			b.v = b.withStability(flag)
+			lower = b.v
+		}
+	}
+
+	exact := Range{lower: b.v, hasLower: true, equalsLower: true, upper: b.v, hasUpper: true, equalsUpper: true}
+	switch operator {
+	case "", "=", "==":
+		if !b.wildcard {
+			return RangeSet{exact}, stability, nil
+		}
+		return RangeSet{{lower: lower, hasLower: true, equalsLower: true, upper: nextPrefix(b.v, b.columns), hasUpper: true}}, stability, nil
+	case "!=", "<>":
+		return RangeSet{{upper: b.v, hasUpper: true}, {lower: b.v, hasLower: true}}, stability, nil
+	case ">":
+		return RangeSet{{lower: b.v, hasLower: true}}, stability, nil
+	case ">=":
+		return RangeSet{{lower: lower, hasLower: true, equalsLower: true}}, stability, nil
+	case "<":
+		return RangeSet{{upper: b.v, hasUpper: true}}, stability, nil
+	case "<=":
+		return RangeSet{{upper: b.v, hasUpper: true, equalsUpper: true}}, stability, nil
+	case "^":
+		// The leftmost non-zero column, or the last given one, must not change.
+		fixed := b.columns
+		for i := 0; i < b.columns; i++ {
+			if b.v.version[i] != 0 {
+				fixed = i + 1
+				break
+			}
+		}
+		return RangeSet{{lower: lower, hasLower: true, equalsLower: true, upper: nextPrefix(b.v, fixed), hasUpper: true}}, stability, nil
+	}
+	// "~" lets the last given column change, or the minor if only the major is given.
+	fixed := b.columns - 1
+	if fixed < 1 {
+		fixed = 1
+	}
+	return RangeSet{{lower: lower, hasLower: true, equalsLower: true, upper: nextPrefix(b.v, fixed), hasUpper: true}}, stability, nil
+}
+
+// newComposerHyphenRange reads "A - B", of which the version B starts at rightStart.
+//
+// B is inclusive if it has at least three columns or a stability suffix,
+// else what follows the last given column is excluded.
+func newComposerHyphenRange(str []byte, leftEnd, rightStart int) (RangeSet, Stability, error) {
+	left, err := newComposerBound(str[:leftEnd])
+	if err == nil && left.wildcard {
+		err = newParseError(errInvalidComposerConstraint, str, 0, "a version without wildcards")
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	right, err := newComposerBound(str[rightStart:])
+	if err == nil && right.wildcard {
+		err = newParseError(errInvalidComposerConstraint, str[rightStart:], 0, "a version without wildcards")
+	}
+	if err != nil {
+		return nil, 0, atOffset(err, str, rightStart)
+	}
+
+	stability := left.v.Stability()
+	if s := right.v.Stability(); s < stability {
+		stability = s
+	}
+	r := Range{lower: left.lowest(), hasLower: true, equalsLower: true, upper: right.v, hasUpper: true, equalsUpper: true}
+	if right.columns < 3 && !right.hasModifier {
+		r.upper, r.equalsUpper = nextPrefix(right.v, right.columns), false
+	}
+	return RangeSet{r}, stability, nil
+}
+
+// cutComposerFlag strips any stability flag, such as "@beta", and returns it,
+// or StabilityStable if there is none.
+func cutComposerFlag(str []byte) ([]byte, Stability, error) {
+	idx := bytes.LastIndexByte(str, '@')
+	if idx < 0 {
+		return str, StabilityStable, nil
+	}
+	stability, known := stabilityValue[strings.ToLower(string(str[idx+1:]))]
+	if !known {
+		return nil, 0, newParseError(errInvalidComposerConstraint, str, idx+1, "stable, RC, beta, alpha, or dev")
+	}
+	return str[:idx], stability, nil
+}
+
+// composerBound is a version in a ComposerConstraint.
+type composerBound struct {
+	v           Version
+	columns     int
+	wildcard    bool // Any columns after the given ones are "*" or "x".
+	hasModifier bool // A stability suffix has been given, such as in "1.0-beta" or "1.0-stable".
+}
+
+// lowest returns the lowest Version the bound includes as lower boundary,
+// which without stability suffix is its lowest pre-release.
+func (b composerBound) lowest() Version {
+	if b.hasModifier {
+		return b.v
+	}
+	return b.withStability(StabilityDev)
+}
+
+// withStability returns the bound's Version with the stability suffix of the given Stability,
+// which for StabilityDev is its lowest pre-release "-0".
+func (b composerBound) withStability(s Stability) Version {
+	v := b.v
+	switch s {
+	case StabilityDev:
+		v.version[idxReleaseType], v.preRelease = identified, "0"
+	case StabilityAlpha:
+		v.version[idxReleaseType] = alpha
+	case StabilityBeta:
+		v.version[idxReleaseType] = beta
+	case StabilityRC:
+		v.version[idxReleaseType] = rc
+	}
+	return v
+}
+
+// newComposerBound reads a version of up to four columns, with either trailing wildcards
+// or a stability suffix, and ignores any build metadata.
+func newComposerBound(str []byte) (composerBound, error) {
+	var b composerBound
+	idx := 0
+	if len(str) > 0 && str[0]|0x20 == 'v' {
+		idx++
+	}
+	for ; idx < len(str); idx++ {
+		if b.columns > 0 {
+			if str[idx] != '.' {
+				break
+			}
+			idx++
+		}
+		if idx < len(str) && b.columns > 0 && (str[idx] == '*' || str[idx]|0x20 == 'x') {
+			b.wildcard = true
+			continue
+		}
+		n, num := atoui(str[idx:])
+		switch {
+		case n == 0 && b.wildcard:
+			return b, newParseError(errInvalidComposerConstraint, str, idx, "a wildcard")
+		case n == 0:
+			return b, newParseError(errInvalidComposerConstraint, str, idx, "a digit")
+		case b.wildcard:
+			return b, newParseError(errInvalidComposerConstraint, str, idx, "a wildcard")
+		case n >= 10:
+			return b, newParseError(errInvalidComposerConstraint, str, idx+9, "at most 9 digits")
+		case b.columns >= 4:
+			return b, newParseError(errTooManyColumns, str, idx-1, "at most four columns")
+		}
+		b.v.version[b.columns] = int32(num)
+		b.columns++
+		idx += n - 1
+	}
+	if b.columns == 0 {
+		return b, newParseError(errInvalidComposerConstraint, str, idx, "a digit")
+	}
+	if end := bytes.IndexByte(str, '+'); end >= idx && end+1 < len(str) {
+		str = str[:end]
+	}
+	if idx >= len(str) {
+		return b, nil
+	}
+	if b.wildcard {
+		return b, newParseError(errInvalidComposerConstraint, str, idx, "the end")
+	}
+
+	// The stability suffix.
+	start := idx
+	if str[idx] == '.' || str[idx] == '-' || str[idx] == '_' {
+		idx++
+	}
+	n := 0
+	for idx+n < len(str) && isSmallLetter(str[idx+n]|0x20) {
+		n++
+	}
+	word := strings.ToLower(string(str[idx : idx+n]))
+	typ, known := composerModifiers[word]
+	switch {
+	case word == "dev":
+		b.v, b.hasModifier = b.lowest(), true
+		idx += n
+	case !known:
+		return b, newParseError(errInvalidComposerConstraint, str, start, "a stability suffix such as -beta, -RC, or -dev")
+	default:
+		b.hasModifier = true
+		b.v.version[idxReleaseType] = int32(typ)
+		idx += n
+		if idx+1 < len(str) && (str[idx] == '.' || str[idx] == '-') && isNumeric(str[idx+1]) {
+			idx++
+		}
+		n, num := atoui(str[idx:])
+		if n >= 10 {
+			return b, newParseError(errInvalidComposerConstraint, str, idx+9, "at most 9 digits")
+		}
+		b.v.version[idxRelease] = int32(num)
+		idx += n
+	}
+	if idx < len(str) {
+		return b, newParseError(errInvalidComposerConstraint, str, idx, "the end")
+	}
+	return b, nil
+}
+
+// RangeSet returns the Ranges of this constraint, of which a Version must be in any.
+func (c ComposerConstraint) RangeSet() RangeSet {
+	return c.set
+}
+
+// Stability returns the lowest stability that the constraint asks for by its flags and pre-releases,
+// such as StabilityBeta for "^1.2@beta" or ">=1.0-beta2", else StabilityStable.
+func (c ComposerConstraint) Stability() Stability {
+	return c.stability
+}
+
+// Contains returns true if the Version is in any of the Ranges, regardless of its stability.
+//
+// If in doubt use IsSatisfiedBy.
+func (c ComposerConstraint) Contains(v Version) bool {
+	return c.set.Contains(v)
+}
+
+// IsSatisfiedBy works like Contains, but rejects any Version less stable
+// than Composer's default minimum-stability "stable", or than the constraint asks for.
+func (c ComposerConstraint) IsSatisfiedBy(v Version) bool {
+	return c.IsSatisfiedWith(v, StabilityStable)
+}
+
+// IsSatisfiedWith works like IsSatisfiedBy, but with the given minimum-stability,
+// which the constraint's flags and pre-releases can lower further.
+func (c ComposerConstraint) IsSatisfiedWith(v Version, minimumStability Stability) bool {
+	if c.stability < minimumStability {
+		minimumStability = c.stability
+	}
+	return v.Stability() >= minimumStability && c.set.Contains(v)
+}
+
+// String returns the constraint as it has been read, without surrounding whitespace.
+func (c ComposerConstraint) String() string {
+	return c.str
+}
@@ -0,0 +1,164 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewComposerConstraint(t *testing.T) {
+	Convey("Composer constraints contain…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"^1.2 | ^2.0": {"1.2.0": true, "1.9.9": true, "2.5.0": true, "3.0.0": false, "1.1.9": false, "2.0.0-beta1": true},
+			">=1.0 <1.1 || >=1.2": {
+				"1.0.5": true, "1.1.0": false, "1.1.5": false, "1.2.0": true, "5.0.0": true,
+				"1.0.0-beta1": true, "1.1.0-beta1": false,
+			},
+			"1.0.*":            {"1.0.0": true, "1.0.9": true, "1.0.0-alpha": true, "1.1.0": false, "0.9.0": false},
+			"1.*":              {"1.9.0": true, "2.0.0": false},
+			"*":                {"0.0.1": true, "99.0.0": true},
+			"1.0.0":            {"1.0.0": true, "1.0.1": false, "1.0.0-rc1": false},
+			"==v1.0":           {"1.0.0": true, "1.0.1": false},
+			"1.2.3.4":          {"1.2.3.4": true, "1.2.3.5": false},
+			"~1.2.3":           {"1.2.3": true, "1.2.9": true, "1.3.0": false, "1.2.2": false},
+			"~1.2":             {"1.9.0": true, "2.0.0": false, "1.1.0": false},
+			"~1":               {"1.9.0": true, "2.0.0": false},
+			"~1.2.3.4":         {"1.2.3.9": true, "1.2.4.0": false},
+			"^0.3":             {"0.3.5": true, "0.4.0": false},
+			"^0.0.3":           {"0.0.3": true, "0.0.4": false},
+			"^0.0":             {"0.0.9": true, "0.1.0": false},
+			"!=1.5":            {"1.5.0": false, "1.4.0": true, "1.6.0": true},
+			"<>1.5":            {"1.5.0": false, "1.6.0": true},
+			">=1.0, !=1.5, <2": {"1.5.0": false, "1.6.0": true, "1.2.0": true, "2.0.0": false, "0.9.0": false},
+			">= 1.0 < 2":       {"1.5.0": true, "2.0.0": false},
+			"1.0 - 2.0":        {"1.0.0": true, "2.0.9": true, "2.1.0": false, "0.9.0": false},
+			"1.0.0 - 2.1.0":    {"2.1.0": true, "2.1.1": false},
+			">1.0":             {"1.0.0": false, "1.0.1": true},
+			"<=1.0":            {"1.0.0": true, "1.0.1": false},
+			">=1.0-beta2":      {"1.0.0-beta2": true, "1.0.0-beta1": false, "1.0.0-alpha": false, "1.0.0": true},
+			">=1.0.0-RC":       {"1.0.0-rc1": true, "1.0.0-beta": false},
+			">=1.0-stable":     {"1.0.0": true, "1.0.0-rc1": false},
+			">=1.0-dev":        {"1.0.0-alpha": true, "0.9.9": false},
+			">=1.0@beta":       {"1.0.0-beta": true, "1.0.0-alpha": false},
+			"<1.0@beta":        {"1.0.0-alpha": true, "1.0.0-beta": false},
+			"1.0.0+build":      {"1.0.0": true},
+		} {
+			c, err := ParseComposerConstraint(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(c.Contains(mustVersion(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("IsSatisfiedBy rejects what is less stable than asked for…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"^1.2":              {"1.3.0": true, "1.3.0-beta1": false},
+			"^1.2@beta":         {"1.3.0-beta1": true, "1.3.0-RC1": true, "1.3.0-alpha1": false, "1.3.0-dev": false},
+			"^1.2@dev":          {"1.3.0-dev": true, "1.3.0-nightly.1": true},
+			">=1.0-beta2":       {"1.5.0-beta1": true, "1.5.0-alpha1": false},
+			">=1.0-RC1":         {"1.5.0-beta1": false, "1.5.0-rc1": true},
+			"1.0.*@dev":         {"1.0.5-alpha": true},
+			"^1.2 | ^2.0@beta":  {"1.5.0-beta": true},
+			"^1.2, <1.5@stable": {"1.3.0-rc1": false},
+		} {
+			c, err := ParseComposerConstraint(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(c.IsSatisfiedBy(mustVersion(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("IsSatisfiedWith takes a minimum-stability", t, func() {
+		c, _ := ParseComposerConstraint("^1.2")
+		So(c.IsSatisfiedWith(mustVersion("1.3.0-beta1"), StabilityBeta), ShouldBeTrue)
+		So(c.IsSatisfiedWith(mustVersion("1.3.0-alpha1"), StabilityBeta), ShouldBeFalse)
+		So(c.IsSatisfiedWith(mustVersion("1.3.0-dev"), StabilityDev), ShouldBeTrue)
+		So(c.IsSatisfiedWith(mustVersion("2.0.0-dev"), StabilityDev), ShouldBeFalse)
+	})
+
+	Convey("Composer constraints tell…", t, func() {
+		Convey("their stability", func() {
+			for str, stability := range map[string]Stability{
+				"^1.2":                StabilityStable,
+				"^1.2@beta || ^2@dev": StabilityDev,
+				">=1.0-RC1":           StabilityRC,
+				"1.0.0-alpha3":        StabilityAlpha,
+				"1.0@stable":          StabilityStable,
+			} {
+				c, err := ParseComposerConstraint(str)
+				So(err, ShouldBeNil)
+				So(c.Stability(), ShouldEqual, stability)
+			}
+		})
+
+		Convey("their Ranges", func() {
+			c, _ := ParseComposerConstraint("1.0.* || ^2.1")
+			set := c.RangeSet()
+			So(set, ShouldHaveLength, 2)
+			So(set[0].GetLowerBoundary().String(), ShouldEqual, "1.0.0-0")
+			So(set[0].GetUpperBoundary().String(), ShouldEqual, "1.1.0")
+			So(set[1].GetLowerBoundary().String(), ShouldEqual, "2.1.0-0")
+			So(set[1].GetUpperBoundary().String(), ShouldEqual, "3.0.0")
+		})
+
+		Convey("how they have been written", func() {
+			c, _ := ParseComposerConstraint("  ^1.2 | ^2.0@dev ")
+			So(c.String(), ShouldEqual, "^1.2 | ^2.0@dev")
+		})
+	})
+
+	Convey("NewComposerConstraint rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":           0,
+			"~>1.2":      1,
+			"^1.2.*":     0,
+			"1.0@foo":    4,
+			"1.0 ||":     6,
+			"dev-master": 0,
+			"1.0.x-dev":  5,
+			">=1.0 <x":   7,
+			"1.2.3.4.5":  7,
+			"1.0-foo":    3,
+			"1.0 - ":     4,
+			"1.0 - 2.*":  6,
+		} {
+			Convey(str, func() {
+				_, err := ParseComposerConstraint(str)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Input, ShouldEqual, str)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
+
+func TestVersionStability(t *testing.T) {
+	Convey("Versions have a Stability", t, FailureContinues, func() {
+		for str, stability := range map[string]Stability{
+			"1.0.0":         StabilityStable,
+			"1.0.0-p1":      StabilityStable,
+			"1.0.0-alpha1":  StabilityAlpha,
+			"1.0.0-b1":      StabilityBeta,
+			"1.0.0-pre":     StabilityBeta,
+			"1.0.0-RC.1":    StabilityRC,
+			"1.0.0-dev":     StabilityDev,
+			"1.0.0-nightly": StabilityDev,
+		} {
+			So(mustVersion(str).Stability(), ShouldEqual, stability)
+		}
+		So(StabilityRC.String(), ShouldEqual, "RC")
+		So(Stability(9).String(), ShouldEqual, "")
+	})
+}
@@ -0,0 +1,261 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Errors that are thrown when reading a DebianVersion.
+const (
+	errInvalidDebianVersion InvalidStringValue = "Given string does not resemble a Debian version"
+	errInvalidDebianType    InvalidStringValue = "Cannot read this type into a DebianVersion"
+)
+
+// DebianVersion is a version of a Debian package, such as "1:2.30-1ubuntu1~20.04",
+// in the format "[epoch:]upstream_version[-debian_revision]" as deb-version(7) describes it.
+type DebianVersion struct {
+	epoch    int
+	upstream string
+	revision string
+}
+
+// NewDebianVersion reads a version of a Debian package.
+//
+// The epoch is a number, and the upstream version must start with a digit.
+// The upstream version can contain letters, digits, and any of ".+~-:",
+// but a hyphen only if a revision follows, and a colon only after an epoch.
+// Any revision consists of letters, digits, and ".+~".
+func NewDebianVersion(str []byte) (DebianVersion, error) {
+	var v DebianVersion
+	upstreamStart, upstreamEnd := 0, len(str)
+	if idx := bytes.IndexByte(str, ':'); idx >= 0 {
+		if n := countDigits(str[:idx]); n < idx || n == 0 {
+			return DebianVersion{}, newParseError(errInvalidDebianVersion, str, n, "a number as epoch")
+		}
+		epoch, err := strconv.ParseInt(string(str[:idx]), 10, 32)
+		if err != nil {
+			return DebianVersion{}, newParseError(errInvalidDebianVersion, str, 0, "an epoch that fits into 31 bits")
+		}
+		v.epoch, upstreamStart = int(epoch), idx+1
+	}
+	if idx := bytes.LastIndexByte(str, '-'); idx >= upstreamStart {
+		if idx+1 == len(str) {
+			return DebianVersion{}, newParseError(errInvalidDebianVersion, str, idx+1, "a revision")
+		}
+		for i := idx + 1; i < len(str); i++ {
+			if !isDebianChar(str[i], ".+~") {
+				return DebianVersion{}, newParseError(errInvalidDebianVersion, str, i, "a letter, digit, or any of .+~")
+			}
+		}
+		v.revision, upstreamEnd = string(str[idx+1:]), idx
+	}
+
+	if upstreamStart >= upstreamEnd || !isNumeric(str[upstreamStart]) {
+		return DebianVersion{}, newParseError(errInvalidDebianVersion, str, upstreamStart, "a digit")
+	}
+	permitted := ".+~-:"
+	if upstreamStart == 0 {
+		permitted = ".+~-"
+	}
+	for i := upstreamStart; i < upstreamEnd; i++ {
+		if !isDebianChar(str[i], permitted) {
+			return DebianVersion{}, newParseError(errInvalidDebianVersion, str, i, "a letter, digit, or any of "+permitted)
+		}
+	}
+	v.upstream = string(str[upstreamStart:upstreamEnd])
+	return v, nil
+}
+
+// ParseDebian is NewDebianVersion for strings.
+func ParseDebian(str string) (DebianVersion, error) {
+	return NewDebianVersion([]byte(str))
+}
+
+func isDebianChar(ch byte, punctuation string) bool {
+	return isNumeric(ch) || isSmallLetter(ch|0x20) || strings.IndexByte(punctuation, ch) >= 0
+}
+
+// Epoch returns the epoch, which is 0 if none has been given.
+func (v DebianVersion) Epoch() int {
+	return v.epoch
+}
+
+// Upstream returns the upstream version, such as "2.30" in "1:2.30-1ubuntu1".
+func (v DebianVersion) Upstream() string {
+	return v.upstream
+}
+
+// Revision returns the Debian revision, such as "1ubuntu1" in "1:2.30-1ubuntu1",
+// or the empty string if there's none.
+func (v DebianVersion) Revision() string {
+	return v.revision
+}
+
+// Compare returns the signum of the difference between v and o, as dpkg orders them:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// Epochs are compared first as numbers, then the upstream versions and revisions,
+// each by alternating between non-digits and numbers. Non-digits are ordered
+// by ASCII with letters before anything but '~', which sorts before everything,
+// even the end of a part: 1.0~rc1 < 1.0 < 1.0a < 1.0+b1.
+func (v DebianVersion) Compare(o DebianVersion) int {
+	if v.epoch != o.epoch {
+		return signum(v.epoch - o.epoch)
+	}
+	if c := compareDebianPart(v.upstream, o.upstream); c != 0 {
+		return c
+	}
+	return compareDebianPart(v.revision, o.revision)
+}
+
+// Less is a convenience function for sorting.
+func (v *DebianVersion) Less(o *DebianVersion) bool {
+	return v.Compare(*o) < 0
+}
+
+// debianOrder is the weight of a non-digit, with 0 for the end of the part and any digit.
+func debianOrder(str string, idx int) int {
+	switch {
+	case idx >= len(str) || isNumeric(str[idx]):
+		return 0
+	case isSmallLetter(str[idx] | 0x20):
+		return int(str[idx])
+	case str[idx] == '~':
+		return -1
+	}
+	return int(str[idx]) + 256
+}
+
+// compareDebianPart is dpkg's verrevcmp.
+func compareDebianPart(a, b string) int {
+	var i, j int
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isNumeric(a[i])) || (j < len(b) && !isNumeric(b[j])) {
+			if ac, bc := debianOrder(a, i), debianOrder(b, j); ac != bc {
+				return signum(ac - bc)
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		firstDiff := 0
+		for i < len(a) && isNumeric(a[i]) && j < len(b) && isNumeric(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		switch {
+		case i < len(a) && isNumeric(a[i]):
+			return 1
+		case j < len(b) && isNumeric(b[j]):
+			return -1
+		case firstDiff != 0:
+			return signum(firstDiff)
+		}
+	}
+	return 0
+}
+
+// serialize returns the canonical representation, which omits a zero epoch.
+func (v DebianVersion) serialize() []byte {
+	target := make([]byte, 0, len(v.upstream)+len(v.revision)+16)
+	if v.epoch != 0 {
+		target = strconv.AppendInt(target, int64(v.epoch), 10)
+		target = append(target, ':')
+	}
+	target = append(target, v.upstream...)
+	if v.revision != "" {
+		target = append(target, '-')
+		target = append(target, v.revision...)
+	}
+	return target
+}
+
+// String returns the canonical string representation of v,
+// which omits a zero epoch.
+func (v DebianVersion) String() string {
+	return string(v.serialize())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v DebianVersion) MarshalJSON() ([]byte, error) {
+	return quoteJSON(v.String()), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v DebianVersion) MarshalText() ([]byte, error) {
+	return v.serialize(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *DebianVersion) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, v.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *DebianVersion) UnmarshalText(b []byte) error {
+	dv, err := NewDebianVersion(b)
+	if err != nil {
+		return err
+	}
+	*v = dv
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *DebianVersion) Scan(src interface{}) error {
+	return scanText(src, v.UnmarshalText, errInvalidDebianType)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (v DebianVersion) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// DebianVersionPtrs represents an array with elements derived from DebianVersion.
+// Use it to sort them, in the order dpkg does.
+type DebianVersionPtrs []*DebianVersion
+
+// Len implements the sort.Interface.
+func (p DebianVersionPtrs) Len() int {
+	return len(p)
+}
+
+// Swap implements the sort.Interface.
+func (p DebianVersionPtrs) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements the sort.Interface.
+func (p DebianVersionPtrs) Less(i, j int) bool {
+	if p[i] == nil {
+		return false
+	} else if p[j] == nil {
+		return true
+	}
+	return p[i].Less(p[j])
+}
+
+// Sort reorders the pointers so that the DebianVersions appear in ascending order.
+// Any nil pointers go last.
+func (p DebianVersionPtrs) Sort() {
+	sort.Stable(p)
+}
@@ -0,0 +1,186 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParseDebian(str string) DebianVersion {
+	v, err := ParseDebian(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestNewDebianVersion(t *testing.T) {
+	Convey("NewDebianVersion reads…", t, FailureContinues, func() {
+		for str, parts := range map[string][3]interface{}{
+			"1.0":                   {0, "1.0", ""},
+			"1:2.30-1ubuntu1~20.04": {1, "2.30", "1ubuntu1~20.04"},
+			"0:1.0-1":               {0, "1.0", "1"},
+			"1.2-3-4":               {0, "1.2-3", "4"},
+			"2:1.0:rc1-2+b1":        {2, "1.0:rc1", "2+b1"},
+			"1.0~rc1+dfsg":          {0, "1.0~rc1+dfsg", ""},
+			"7.4.1689-3ubuntu1.5":   {0, "7.4.1689", "3ubuntu1.5"},
+		} {
+			Convey(str, func() {
+				v, err := ParseDebian(str)
+				So(err, ShouldBeNil)
+				So(v.Epoch(), ShouldEqual, parts[0])
+				So(v.Upstream(), ShouldEqual, parts[1])
+				So(v.Revision(), ShouldEqual, parts[2])
+			})
+		}
+	})
+
+	Convey("NewDebianVersion rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":              0,
+			":1.0":          0,
+			"a1.0":          0,
+			"1.0-":          4,
+			"1:":            2,
+			"-1":            0,
+			"1.0 ":          3,
+			"1.0_1":         3,
+			"1.0-1_2":       5,
+			"1.0:1":         1,
+			"1:1.0-1:2":     7,
+			"99999999999:1": 0,
+			"+1:1.0":        0,
+		} {
+			Convey(str, func() {
+				_, err := ParseDebian(str)
+				So(errors.Is(err, errInvalidDebianVersion), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
+
+func TestDebianVersionOrder(t *testing.T) {
+	Convey("Debian versions are ordered as dpkg does with…", t, FailureContinues, func() {
+		for _, pair := range [][2]string{
+			{"1.0~~", "1.0~~a"},
+			{"1.0~~a", "1.0~"},
+			{"1.0~", "1.0"},
+			{"1.0~rc1", "1.0"},
+			{"1.0", "1.0a"},
+			{"1.0a", "1.0+b1"},
+			{"1.0", "1.0.1"},
+			{"1.9", "1.10"},
+			{"10.3", "1:0.4"},
+			{"1.2.3-1", "1.2.3-1ubuntu1"},
+			{"2.30-1ubuntu1~20.04", "2.30-1ubuntu1"},
+			{"1.0-1", "1.0-1.1"},
+			{"1.0-9", "1.0-10"},
+			{"1.0-1", "1.0a-0"},
+			{"0.0.1-0", "1.2"},
+			{"1.0", "1.0-0.1"},
+		} {
+			Convey(pair[0]+" < "+pair[1], func() {
+				a, b := mustParseDebian(pair[0]), mustParseDebian(pair[1])
+				So(a.Compare(b), ShouldEqual, -1)
+				So(b.Compare(a), ShouldEqual, 1)
+				So(a.Less(&b), ShouldBeTrue)
+				So(b.Less(&a), ShouldBeFalse)
+			})
+		}
+
+		for _, pair := range [][2]string{
+			{"0:1.0-1", "1.0-1"},
+			{"1.0-0", "1.0"},
+			{"1.001", "1.1"},
+			{"1.0-00", "1.0"},
+		} {
+			Convey(pair[0]+" = "+pair[1], func() {
+				a, b := mustParseDebian(pair[0]), mustParseDebian(pair[1])
+				So(a.Compare(b), ShouldEqual, 0)
+				So(b.Compare(a), ShouldEqual, 0)
+			})
+		}
+	})
+
+	Convey("DebianVersionPtrs sorts, nil last", t, func() {
+		ordered := []string{"1.0~rc1", "1.0", "1.0-1", "1.0+b1", "1.1", "1:0.1"}
+		ptrs := make(DebianVersionPtrs, 0, len(ordered)+1)
+		ptrs = append(ptrs, nil)
+		for i := len(ordered) - 1; i >= 0; i-- {
+			v := mustParseDebian(ordered[i])
+			ptrs = append(ptrs, &v)
+		}
+		ptrs.Sort()
+		for i := range ordered {
+			So(ptrs[i].String(), ShouldEqual, ordered[i])
+		}
+		So(ptrs[len(ordered)], ShouldBeNil)
+	})
+}
+
+func TestDebianVersionSerialization(t *testing.T) {
+	Convey("DebianVersion is written…", t, FailureContinues, func() {
+		Convey("without a zero epoch, which still compares equal", func() {
+			v := mustParseDebian("0:1.0-1")
+			So(v.String(), ShouldEqual, "1.0-1")
+			So(v.Compare(mustParseDebian(v.String())), ShouldEqual, 0)
+		})
+
+		Convey("with colons and hyphens in the upstream version intact", func() {
+			for _, str := range []string{"2:1.0:rc1-2+b1", "1.2-3-4", "1:2.30-1ubuntu1~20.04"} {
+				v := mustParseDebian(str)
+				text, err := v.MarshalText()
+				So(err, ShouldBeNil)
+				So(string(text), ShouldEqual, str)
+				var back DebianVersion
+				So(back.UnmarshalText(text), ShouldBeNil)
+				So(back.Upstream(), ShouldEqual, v.Upstream())
+				So(back.Revision(), ShouldEqual, v.Revision())
+			}
+		})
+	})
+
+	Convey("DebianVersions are read from JSON…", t, func() {
+		Convey("as values of a map by package name", func() {
+			var installed map[string]DebianVersion
+			So(json.Unmarshal([]byte(`{"libc6": "2.31-0ubuntu9.9", "tzdata": "2024a-0ubuntu0.20.04"}`), &installed), ShouldBeNil)
+			So(installed["libc6"].Revision(), ShouldEqual, "0ubuntu9.9")
+			So(installed["tzdata"].Upstream(), ShouldEqual, "2024a")
+
+			out, err := json.Marshal(installed)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `{"libc6":"2.31-0ubuntu9.9","tzdata":"2024a-0ubuntu0.20.04"}`)
+		})
+
+		Convey("leaving them alone on null", func() {
+			v := mustParseDebian("1:1.0")
+			So(json.Unmarshal([]byte("null"), &v), ShouldBeNil)
+			So(v.Epoch(), ShouldEqual, 1)
+		})
+
+		Convey("but not with whitespace", func() {
+			var v DebianVersion
+			So(json.Unmarshal([]byte(`"1.0 "`), &v), ShouldNotBeNil)
+		})
+	})
+
+	Convey("DebianVersion is scanned from databases", t, func() {
+		v := mustParseDebian("1:2.30-1ubuntu1~20.04")
+		val, err := v.Value()
+		So(err, ShouldBeNil)
+		var back DebianVersion
+		So(back.Scan(val), ShouldBeNil)
+		So(back, ShouldResemble, v)
+		So(errors.Is(back.Scan([]byte("1.0-")), errInvalidDebianVersion), ShouldBeTrue)
+		So(back.Scan(int64(1)), ShouldEqual, errInvalidDebianType)
+	})
+}
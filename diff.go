@@ -0,0 +1,67 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+// Change is the most significant difference between two Versions, as reported by Diff.
+// The greater the value, the more significant the Change.
+type Change int
+
+// Changes in order of significance.
+const (
+	NoChange         Change = iota
+	BuildChange             // Only the build metadata differs, such as in 1.2.3+build1 and 1.2.3+build2.
+	PreReleaseChange        // The release type or its numbers, such as in 1.2.3-rc1, 1.2.3-rc2 and 1.2.3-p1.
+	RevisionChange          // The fourth column, such as in 1.2.3.4.
+	PatchChange
+	MinorChange
+	MajorChange
+)
+
+var changeDesc = [...]string{
+	NoChange:         "none",
+	BuildChange:      "build",
+	PreReleaseChange: "pre-release",
+	RevisionChange:   "revision",
+	PatchChange:      "patch",
+	MinorChange:      "minor",
+	MajorChange:      "major",
+}
+
+// String implements the fmt.Stringer interface.
+func (c Change) String() string {
+	if c < 0 || int(c) >= len(changeDesc) {
+		return "unknown"
+	}
+	return changeDesc[c]
+}
+
+// Diff tells which is the most significant field that differs between the two Versions,
+// such as MinorChange for 1.2.3 and 1.3.0, or PreReleaseChange for 1.3.0-rc1 and 1.3.0.
+//
+// The direction is 1 if b is greater than a, like in an upgrade from a to b,
+// -1 if b is lower, and 0 if the two are equal by Compare.
+// For a BuildChange only two +buildNNN are ordered, by their number, as Less does.
+func Diff(a, b Version) (Change, int) {
+	for i := range a.version {
+		if a.version[i] == b.version[i] {
+			continue
+		}
+		if i < idxReleaseType { // The columns are in order Major, Minor, Patch, Revision.
+			return MajorChange - Change(i), Compare(&b, &a)
+		}
+		return PreReleaseChange, Compare(&b, &a)
+	}
+	if a.preRelease != b.preRelease {
+		return PreReleaseChange, Compare(&b, &a)
+	}
+
+	if a.build != b.build || a.buildMetadata != b.buildMetadata {
+		if a.buildMetadata == "" && b.buildMetadata == "" {
+			return BuildChange, signum(int(b.build) - int(a.build))
+		}
+		return BuildChange, 0
+	}
+	return NoChange, 0
+}
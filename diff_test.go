@@ -0,0 +1,65 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDiff(t *testing.T) {
+	type expectation struct {
+		change    Change
+		direction int
+	}
+
+	Convey("Diff works with…", t, FailureContinues, func() {
+		for pair, exp := range map[[2]string]expectation{
+			{"1.2.3", "2.0.0"}:                    {MajorChange, 1},
+			{"2.0.0", "1.9.9"}:                    {MajorChange, -1},
+			{"1.2.3", "1.3.0"}:                    {MinorChange, 1},
+			{"1.2.3", "1.2.4-rc1"}:                {PatchChange, 1},
+			{"1.2.3", "1.2.3.1"}:                  {RevisionChange, 1},
+			{"1.2.3.1", "1.2.3"}:                  {RevisionChange, -1},
+			{"1.2.3-rc1", "1.2.3"}:                {PreReleaseChange, 1},
+			{"1.2.3-rc1", "1.2.3-rc2"}:            {PreReleaseChange, 1},
+			{"1.2.3-rc1", "1.2.3-beta5"}:          {PreReleaseChange, -1},
+			{"1.2.3-p1", "1.2.3"}:                 {PreReleaseChange, 1},
+			{"1.2.3-rc1", "1.2.3-rc1-p1"}:         {PreReleaseChange, 1},
+			{"1.0.0-alpha", "1.0.0-x.7"}:          {PreReleaseChange, 1},
+			{"1.0.0-x.7", "1.0.0-x.8"}:            {PreReleaseChange, 1},
+			{"1.0.0-x.7", "1.0.0-x.07"}:           {PreReleaseChange, 0},
+			{"1.2.3+build1", "1.2.3+build2"}:      {BuildChange, 1},
+			{"1.2.3+build2", "1.2.3"}:             {BuildChange, -1},
+			{"1.2.3+sha.5114f85", "1.2.3+build2"}: {BuildChange, 0},
+			{"1.2.3", "v1.2.3"}:                   {NoChange, 0},
+			{"1.0.0-x.7+a", "1.0.0-x.7+a"}:        {NoChange, 0},
+		} {
+			Convey(pair[0]+" to "+pair[1], func() {
+				a, _ := NewVersionWithIdentifiers([]byte(pair[0]))
+				b, _ := NewVersionWithIdentifiers([]byte(pair[1]))
+				change, direction := Diff(a, b)
+				So(change, ShouldEqual, exp.change)
+				So(direction, ShouldEqual, exp.direction)
+
+				change, direction = Diff(b, a)
+				So(change, ShouldEqual, exp.change)
+				So(direction, ShouldEqual, -exp.direction)
+			})
+		}
+	})
+
+	Convey("Changes are ordered by significance", t, func() {
+		So(MajorChange, ShouldBeGreaterThan, MinorChange)
+		So(MinorChange, ShouldBeGreaterThan, PatchChange)
+		So(PatchChange, ShouldBeGreaterThan, RevisionChange)
+		So(RevisionChange, ShouldBeGreaterThan, PreReleaseChange)
+		So(PreReleaseChange, ShouldBeGreaterThan, BuildChange)
+		So(BuildChange, ShouldBeGreaterThan, NoChange)
+		So(MinorChange.String(), ShouldEqual, "minor")
+		So(Change(42).String(), ShouldEqual, "unknown")
+	})
+}
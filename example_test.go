@@ -5,7 +5,9 @@
 package semver_test
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"blitznote.com/src/semver/v3"
 )
@@ -73,6 +75,15 @@ func ExampleRange_Contains_prerelases() {
 	// Output: true
 }
 
+func ExampleNewRangeSet() {
+	v := semver.MustParse("3.2.0")
+	s, _ := semver.NewRangeSet([]byte("^1.2 || >=3.1 <3.5"))
+
+	fmt.Println(s.IsSatisfiedBy(v))
+
+	// Output: true
+}
+
 func ExampleRange_GetLowerBoundary() {
 	r, _ := semver.NewRange([]byte("^1.2"))
 	fmt.Println(*r.GetLowerBoundary())
@@ -116,6 +127,13 @@ func ExampleRange_IsSatisfiedBy_prerelases() {
 	// Output: false
 }
 
+func ExampleRange_String() {
+	r, _ := semver.NewRange([]byte("~1.2"))
+	fmt.Println(r)
+
+	// Output: >=1.2.0 <1.3.0
+}
+
 func ExampleMustParse() {
 	v := semver.MustParse("v1.14")
 	fmt.Println(v)
@@ -133,7 +151,65 @@ func ExampleNewVersion() {
 	// Output:
 	// 1.14.0 <nil>
 	// 6.0.2.1 <nil>
-	// 14.0.0 Given string does not resemble a Version
+	// 14.0.0 Given string does not resemble a Version: unexpected 'b' at offset 2 of "14b6", expected alpha, beta, pre, rc, r, or p
+}
+
+func ExampleParseError() {
+	_, err := semver.NewRange([]byte(">=1.2.3 <2.0.0-gam_ma"))
+
+	var parseErr *semver.ParseError
+	if errors.As(err, &parseErr) {
+		fmt.Println(parseErr.Input)
+		fmt.Printf("%*s^ expected %s\n", parseErr.Offset, "", parseErr.Expected)
+	}
+
+	// Output:
+	// >=1.2.3 <2.0.0-gam_ma
+	//                   ^ expected identifiers of [0-9A-Za-z-]
+}
+
+func ExampleVersion_Build() {
+	v, _ := semver.NewVersionWithIdentifiers([]byte("1.0.0-rc1+sha.5114f85"))
+	fmt.Println(v.Build())
+
+	// Output: sha.5114f85
+}
+
+func ExampleAtom_IsSatisfiedBy() {
+	atom, _ := semver.ParseAtom(">=dev-lang/go-1.16:0/1.16=")
+	installed, _ := semver.ParseGentoo("1.17.2-r1")
+	fmt.Println(atom.Category(), atom.Package(), atom.IsSatisfiedBy(installed))
+
+	// Output: dev-lang go true
+}
+
+func ExampleParseDebian() {
+	installed, _ := semver.ParseDebian("2.30-1ubuntu1~20.04")
+	candidate, _ := semver.ParseDebian("2.30-1ubuntu1")
+	fmt.Println(installed.Less(&candidate))
+
+	// Output: true
+}
+
+func ExampleParseGentoo() {
+	a, _ := semver.ParseGentoo("1.2.3b_alpha4_pre1_p2-r7")
+	b, _ := semver.ParseGentoo("1.2.3b_alpha4")
+	fmt.Println(a.Less(b)) // A _pre of an _alpha4 is before that _alpha4.
+
+	// Output: true
+}
+
+func ExampleParseStrict() {
+	for _, str := range []string{"1.0.0-rc.1", "v1.0.0", "1.0", "1.02.0"} {
+		_, err := semver.ParseStrict(str)
+		fmt.Println(str, err)
+	}
+
+	// Output:
+	// 1.0.0-rc.1 <nil>
+	// v1.0.0 SemVer 2.0.0: Version must start with a digit, without a prefix such as 'v'
+	// 1.0 SemVer 2.0.0: Version must consist of MAJOR.MINOR.PATCH, which are numbers
+	// 1.02.0 SemVer 2.0.0: Numbers must not have leading zeroes
 }
 
 func ExampleVersion_Bytes_first() {
@@ -232,6 +308,35 @@ func ExampleVersion_Minor() {
 	// Output: 2
 }
 
+func ExampleDiff() {
+	installed, available := semver.MustParse("1.2.3"), semver.MustParse("1.3.0-rc1")
+	change, direction := semver.Diff(installed, available)
+	if direction > 0 {
+		fmt.Println("There is a new", change, "version:", available)
+	}
+
+	// Output: There is a new minor version: 1.3.0-rc1
+}
+
+func ExampleVersion_IncPrerelease() {
+	v := semver.MustParse("1.2.3")
+	v, _ = v.IncPrerelease("beta")
+	fmt.Println(v)
+	v, _ = v.IncPrerelease("beta")
+	fmt.Println(v)
+	v, _ = v.IncPrerelease("rc")
+	fmt.Println(v)
+	fmt.Println(v.IncPatch())
+	fmt.Println(v.IncMinor())
+
+	// Output:
+	// 1.2.4-beta1
+	// 1.2.4-beta2
+	// 1.2.4-rc1
+	// 1.2.4
+	// 1.3.0
+}
+
 func ExampleVersion_Patch() {
 	v := semver.MustParse("v1.2.3")
 	fmt.Println(v.Patch())
@@ -262,3 +367,177 @@ func ExampleVersion_String() {
 	// Output:
 	// v2.1 is 2.1.0 but as Bytes(): 2.1
 }
+
+func ExampleRPMRange_Contains() {
+	kernels, _ := semver.ParseRPMRange(">= 4.18.0-305 < 4.19")
+	for _, str := range []string{"4.18.0-240.el8", "4.18.0-305.3.1.el8", "5.14.0-70.el9"} {
+		v, _ := semver.ParseRPM(str)
+		fmt.Println(str, kernels.Contains(v))
+	}
+
+	// Output:
+	// 4.18.0-240.el8 false
+	// 4.18.0-305.3.1.el8 true
+	// 5.14.0-70.el9 false
+}
+
+func ExamplePEP440SpecifierSet_IsSatisfiedBy() {
+	specifiers, _ := semver.ParsePEP440SpecifierSet("~=1.4.2, !=1.4.5")
+	for _, str := range []string{"1.4.2", "1.4.5", "1.4.6rc1", "1.4.6.post1", "1.5"} {
+		v, _ := semver.ParsePEP440(str)
+		fmt.Println(str, specifiers.IsSatisfiedBy(v))
+	}
+
+	// Output:
+	// 1.4.2 true
+	// 1.4.5 false
+	// 1.4.6rc1 false
+	// 1.4.6.post1 true
+	// 1.5 false
+}
+
+func ExampleMavenRange_Contains() {
+	r, _ := semver.ParseMavenRange("(,1.0],[1.2,)")
+	for _, str := range []string{"1.0.Final", "1.1", "1.2-SNAPSHOT", "1.2-sp1"} {
+		v, _ := semver.ParseMaven(str)
+		fmt.Println(str, r.Contains(v))
+	}
+
+	// Output:
+	// 1.0.Final true
+	// 1.1 false
+	// 1.2-SNAPSHOT false
+	// 1.2-sp1 true
+}
+
+func ExampleModuleVersion_PseudoBase() {
+	v, _ := semver.ParseModuleVersion("v1.2.4-0.20210101120000-abcdef123456")
+	base, _ := v.PseudoBase()
+	revision, _ := v.PseudoRevision()
+	fmt.Println(base, revision)
+	fmt.Println(v.CheckPath("example.com/mod"))
+	fmt.Println(v.CheckPath("example.com/mod/v2"))
+
+	// Output:
+	// v1.2.3 abcdef123456
+	// <nil>
+	// Module path does not match the major version: unexpected '/' at offset 15 of "example.com/mod/v2", expected no major version suffix
+}
+
+func ExampleParseCargoRange() {
+	r, _ := semver.ParseCargoRange("0.3")
+	for _, str := range []string{"0.3.9", "0.4.0", "0.3.1-alpha.1"} {
+		v, _ := semver.NewVersionWithIdentifiers([]byte(str))
+		fmt.Println(str, r.IsSatisfiedBy(v))
+	}
+
+	// Output:
+	// 0.3.9 true
+	// 0.4.0 false
+	// 0.3.1-alpha.1 false
+}
+
+func ExampleGemRequirement_IsSatisfiedBy() {
+	r, _ := semver.ParseGemRequirement("~> 2.2.0")
+	for _, str := range []string{"2.2.9", "2.3", "2.2.10.rc1"} {
+		v, _ := semver.ParseGem(str)
+		fmt.Println(str, r.Contains(v), r.IsSatisfiedBy(v))
+	}
+
+	// Output:
+	// 2.2.9 true true
+	// 2.3 false false
+	// 2.2.10.rc1 true false
+}
+
+func ExampleComposerConstraint_IsSatisfiedWith() {
+	c, _ := semver.ParseComposerConstraint("^1.2 | ^2.0@beta")
+	for _, str := range []string{"1.9.0", "2.1.0-beta2", "2.1.0-alpha1"} {
+		v, _ := semver.NewVersionWithIdentifiers([]byte(str))
+		fmt.Println(str, c.IsSatisfiedBy(v), c.IsSatisfiedWith(v, semver.StabilityDev))
+	}
+
+	// Output:
+	// 1.9.0 true true
+	// 2.1.0-beta2 true true
+	// 2.1.0-alpha1 false true
+}
+
+func ExampleNuGetRange_FindBestMatch() {
+	var versions semver.NuGetVersionPtrs
+	for _, str := range []string{"1.0.0", "1.1.0", "1.2.0-beta", "2.0.0"} {
+		v, _ := semver.ParseNuGet(str)
+		versions = append(versions, &v)
+	}
+
+	for _, str := range []string{"[1.0,2.0)", "1.*", "1.*-*"} {
+		r, _ := semver.ParseNuGetRange(str)
+		fmt.Println(r, r.FindBestMatch(versions))
+	}
+
+	// Output:
+	// [1.0.0, 2.0.0) 1.0.0
+	// [1.*, ) 1.1.0
+	// [1.*-*, ) 1.2.0-beta
+}
+
+func ExampleCalVer_Next() {
+	scheme, _ := semver.NewCalVer("YY.0M.MICRO")
+	v, _ := scheme.Parse("22.04.1")
+
+	for _, t := range []time.Time{
+		time.Date(2022, time.April, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, time.October, 3, 0, 0, 0, 0, time.UTC),
+	} {
+		next, _ := scheme.Next(v, t)
+		fmt.Println(scheme.Format(next))
+	}
+
+	// Output:
+	// 22.04.2
+	// 22.10.0
+}
+
+func ExampleComparatorFor() {
+	for _, ecosystem := range []string{"alpine", "arch"} {
+		result, _ := semver.ComparatorFor(ecosystem).Compare("1.0_rc1", "1.0")
+		fmt.Println(ecosystem, result)
+	}
+
+	// Output:
+	// alpine -1
+	// arch 1
+}
+
+func ExampleSchemeFor() {
+	for _, pair := range [][3]string{
+		{"npm", "^1.2", "1.4.0-nightly.1"},
+		{"pypi", "~=1.4", "1.4.5"},
+		{"rubygems", "~> 2.2, != 2.2.1", "2.2.1"},
+	} {
+		scheme := semver.SchemeFor(pair[0])
+		c, _ := scheme.ParseConstraint(pair[1])
+		v, _ := scheme.ParseVersion(pair[2])
+		fmt.Println(pair[0], c, v, c.IsSatisfiedBy(v))
+	}
+
+	// Output:
+	// npm >=1.2.0 <2.0.0 1.4.0-nightly.1 false
+	// pypi ~=1.4 1.4.5 true
+	// rubygems ~> 2.2, != 2.2.1 2.2.1 false
+}
+
+func ExampleRange_MaxSatisfying() {
+	var versions semver.VersionPtrs
+	for _, str := range []string{"1.2.0", "1.3.0-beta", "1.2.5", "2.0.0", "1.0.0"} {
+		v := semver.MustParse(str)
+		versions = append(versions, &v)
+	}
+	versions.Sort()
+
+	r, _ := semver.NewRange([]byte("^1.2"))
+	fmt.Println(r.MaxSatisfying(versions), r.MinSatisfying(versions), r.Filter(versions))
+
+	// Output:
+	// 1.2.5 1.2.0 [1.2.0 1.2.5]
+}
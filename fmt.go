@@ -5,9 +5,67 @@
 package semver
 
 import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
 	"strconv"
 )
 
+var jsonNull = []byte("null")
+
+// quoteJSON returns text as JSON string, which is how MarshalJSON writes versions and ranges.
+// Only what JSON requires is escaped. json.Marshal still escapes '<', '>' and '&' in the result,
+// as it does for any MarshalJSON, which a json.Encoder with SetEscapeHTML(false) does not.
+func quoteJSON(text string) []byte {
+	const hex = "0123456789abcdef"
+	target := make([]byte, 0, len(text)+2)
+	target = append(target, '"')
+	for i := 0; i < len(text); i++ {
+		switch ch := text[i]; {
+		case ch == '"' || ch == '\\':
+			target = append(target, '\\', ch)
+		case ch < 0x20:
+			target = append(target, `\u00`...)
+			target = append(target, hex[ch>>4], hex[ch&0x0f])
+		default:
+			target = append(target, ch)
+		}
+	}
+	return append(target, '"')
+}
+
+// unmarshalJSONText reads a JSON value by unmarshalText, without any quotes around it.
+// Like encoding/json does for other types, null leaves the target as it is.
+func unmarshalJSONText(b []byte, unmarshalText func([]byte) error) error {
+	switch {
+	case bytes.Equal(b, jsonNull):
+		return nil
+	case len(b) >= 2 && b[0] == '"' && bytes.IndexByte(b, '\\') >= 0:
+		var text string
+		if err := json.Unmarshal(b, &text); err != nil {
+			return err
+		}
+		return unmarshalText([]byte(text))
+	case len(b) >= 2 && (b[0] == '"' || b[0] == '\'' || b[0] == '`'):
+		// The JSON decoder has checked that the closing quote matches.
+		return unmarshalText(b[1 : len(b)-1])
+	}
+	return unmarshalText(b)
+}
+
+// scanText reads a value from a database by unmarshalText,
+// or returns errType if it's no text.
+func scanText(src interface{}, unmarshalText func([]byte) error, errType error) error {
+	switch v := src.(type) {
+	case []byte:
+		return unmarshalText(v)
+	case string:
+		return unmarshalText([]byte(v))
+	}
+
+	return errType
+}
+
 func numDecimalPlaces(n int32) int {
 	var i int
 	for i = 1; n > 9; i++ {
@@ -54,7 +112,9 @@ func (t Version) serialize(minPlaces int, quoted bool) []byte {
 		if idx+4 <= lastNonZero { // X.Y.Z.N - ?a.b.c.d
 			bytesNeeded++
 		}
-		if t.version[idx+4] != 0 { // alpha, beta, …
+		if t.version[idx+4] == identified {
+			bytesNeeded += len(t.preRelease)
+		} else if t.version[idx+4] != 0 { // alpha, beta, …
 			bytesNeeded += len(releaseDesc[int(t.version[idx+4])])
 		}
 
@@ -62,7 +122,9 @@ func (t Version) serialize(minPlaces int, quoted bool) []byte {
 			break
 		}
 	}
-	if t.build != 0 {
+	if t.buildMetadata != "" {
+		bytesNeeded += 1 + len(t.buildMetadata)
+	} else if t.build != 0 {
 		bytesNeeded += len("+build") + numDecimalPlaces(t.build)
 	}
 
@@ -103,7 +165,9 @@ func (t Version) serialize(minPlaces int, quoted bool) []byte {
 		if idx+4 <= lastNonZero {
 			target = append(target, '-')
 		}
-		if t.version[idx+4] != 0 {
+		if t.version[idx+4] == identified {
+			target = append(target, t.preRelease...)
+		} else if t.version[idx+4] != 0 {
 			target = append(target, []byte(releaseDesc[int(t.version[idx+4])])...)
 		}
 
@@ -112,7 +176,10 @@ func (t Version) serialize(minPlaces int, quoted bool) []byte {
 		}
 		minPlaces -= 5
 	}
-	if t.build != 0 {
+	if t.buildMetadata != "" {
+		target = append(target, '+')
+		target = append(target, t.buildMetadata...)
+	} else if t.build != 0 {
 		target = append(target, buildsuffix...)
 		target = strconv.AppendUint(target, uint64(t.build), 10)
 	}
@@ -165,18 +232,22 @@ func (t Version) MarshalText() ([]byte, error) {
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (t *Version) UnmarshalJSON(b []byte) error {
-	if len(b) > 2 && b[0] == '"' || b[0] == '\'' || b[0] == '`' {
-		// We can ignore the closing because the JSON engine will throw an error on any mismatch for us.
-		return t.Parse(string(b[1 : len(b)-1]))
-	}
-	return t.Parse(string(b))
+	return unmarshalJSONText(b, t.UnmarshalText)
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
+//
+// Unlike NewVersion this accepts arbitrary pre-release identifiers,
+// for anything that MarshalText returns to read back,
+// but reads the fixed vocabulary as NewVersion does, such as "1.0.0-rc1".
+// On error t is left as it was.
 func (t *Version) UnmarshalText(b []byte) error {
-	t.version = [14]int32{}
-	t.build = 0
-	return t.unmarshalText(b)
+	v, err := newVocabularyVersion(b)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
 }
 
 // Scan implements the sql.Scanner interface.
@@ -190,11 +261,9 @@ func (t *Version) Scan(src interface{}) error {
 		}
 		return errOutOfBounds
 	case []byte:
-		*t = Version{}
-		return t.unmarshalText(v)
+		return t.UnmarshalText(v)
 	case string:
-		*t = Version{}
-		return t.unmarshalText([]byte(v))
+		return t.UnmarshalText([]byte(v))
 	}
 
 	return errInvalidType
@@ -206,3 +275,76 @@ func (t *Version) Scan(src interface{}) error {
 func (t Version) Value() (interface{}, error) {
 	return t.String(), nil
 }
+
+// serialize builds the canonical representation of this Range,
+// which NewRange reads back into an identical Range.
+func (r Range) serialize() []byte {
+	target := make([]byte, 0, 32)
+
+	switch {
+	case !r.hasLower && !r.hasUpper:
+		target = append(target, '*')
+	case r.hasLower && r.hasUpper && r.equalsLower && r.equalsUpper && r.lower == r.upper:
+		target = append(target, r.lower.serialize(3, false)...)
+	default:
+		if r.hasLower {
+			target = append(target, '>')
+			if r.equalsLower {
+				target = append(target, '=')
+			}
+			target = append(target, r.lower.serialize(3, false)...)
+		}
+		if r.hasLower && r.hasUpper {
+			target = append(target, ' ')
+		}
+		if r.hasUpper {
+			target = append(target, '<')
+			if r.equalsUpper {
+				target = append(target, '=')
+			}
+			target = append(target, r.upper.serialize(3, false)...)
+		}
+	}
+	return target
+}
+
+// String returns the canonical string representation of r,
+// such as ">=1.2.0 <2.0.0", "1.2.3", or "*" for an unbounded Range.
+func (r Range) String() string {
+	return string(r.serialize())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r Range) MarshalJSON() ([]byte, error) {
+	return quoteJSON(r.String()), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r Range) MarshalText() ([]byte, error) {
+	return r.serialize(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *Range) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, r.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *Range) UnmarshalText(b []byte) error {
+	vr, err := NewRange(b)
+	if err != nil {
+		return err
+	}
+	*r = vr
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (r *Range) Scan(src interface{}) error {
+	return scanText(src, r.UnmarshalText, errInvalidRangeType)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (r Range) Value() (driver.Value, error) {
+	return r.String(), nil
+}
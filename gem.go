@@ -0,0 +1,415 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Errors that are thrown when reading a GemVersion or GemRequirement.
+const (
+	errInvalidGemVersion     InvalidStringValue = "Given string does not resemble a version of a Ruby gem"
+	errInvalidGemType        InvalidStringValue = "Cannot read this type into a GemVersion"
+	errInvalidGemRequirement InvalidStringValue = "Given string does not resemble a requirement of a Ruby gem"
+)
+
+// gemSegment is a run of digits, or of letters, in a GemVersion.
+type gemSegment struct {
+	number  string // Digits without leading zeroes, if this is a number.
+	letters string
+}
+
+// GemVersion is a version of a Ruby gem, such as "1.0.0.rc1" or "1.0.a",
+// ordered as RubyGems' Gem::Version does.
+//
+// It has any number of segments, numbers of arbitrary length or letters,
+// and any letter makes it a pre-release.
+type GemVersion struct {
+	str      string
+	segments []gemSegment
+}
+
+// NewGemVersion reads a version of a Ruby gem: numbers and letters, separated by dots,
+// which start with a number. Anything after a hyphen is a pre-release,
+// and the hyphen is read as ".pre.", as RubyGems does.
+// Surrounding whitespace is ignored, and the empty string is "0".
+func NewGemVersion(str []byte) (GemVersion, error) {
+	idx := skipSpace(str, 0)
+	end := idx + len(bytes.TrimRightFunc(str[idx:], unicode.IsSpace))
+	if idx >= end {
+		return newGemVersion("0"), nil
+	}
+
+	if countDigits(str[idx:end]) == 0 {
+		return GemVersion{}, newParseError(errInvalidGemVersion, str, idx, "a digit")
+	}
+	isPreRelease := false
+	for i := idx; i < end; {
+		n := 0
+		for i+n < end && (isNumeric(str[i+n]) || isSmallLetter(str[i+n]|0x20) || (isPreRelease && str[i+n] == '-')) {
+			n++
+		}
+		if n == 0 {
+			return GemVersion{}, newParseError(errInvalidGemVersion, str, i, "a letter or digit")
+		}
+		i += n
+		switch {
+		case i >= end:
+			continue
+		case str[i] == '-' && !isPreRelease:
+			isPreRelease = true
+		case str[i] != '.':
+			return GemVersion{}, newParseError(errInvalidGemVersion, str, i, "'.', '-', or the end")
+		}
+		i++
+		if i == end {
+			return GemVersion{}, newParseError(errInvalidGemVersion, str, i, "a letter or digit")
+		}
+	}
+	return newGemVersion(strings.ReplaceAll(string(str[idx:end]), "-", ".pre.")), nil
+}
+
+// ParseGem is NewGemVersion for strings.
+func ParseGem(str string) (GemVersion, error) {
+	return NewGemVersion([]byte(str))
+}
+
+// newGemVersion splits a valid version into its segments.
+func newGemVersion(str string) GemVersion {
+	v := GemVersion{str: str}
+	for i := 0; i < len(str); {
+		n := 0
+		switch {
+		case isNumeric(str[i]):
+			n = countDigits([]byte(str[i:]))
+			v.segments = append(v.segments, gemSegment{number: trimPEP440Number([]byte(str[i : i+n]))})
+		case isSmallLetter(str[i] | 0x20):
+			for i+n < len(str) && isSmallLetter(str[i+n]|0x20) {
+				n++
+			}
+			v.segments = append(v.segments, gemSegment{letters: str[i : i+n]})
+		default:
+			n = 1
+		}
+		i += n
+	}
+	return v
+}
+
+// fromGemSegments returns the GemVersion of the given segments, which must be numbers.
+func fromGemSegments(segments []gemSegment) GemVersion {
+	numbers := make([]string, len(segments))
+	for i := range segments {
+		numbers[i] = segments[i].number
+	}
+	return newGemVersion(strings.Join(numbers, "."))
+}
+
+// String returns the version as RubyGems writes it, which is how it has been read,
+// but with any hyphen replaced by ".pre.".
+func (v GemVersion) String() string {
+	return v.str
+}
+
+// IsPreRelease is true if the version has any letters, such as "1.0.a" or "1.0.0.rc1".
+func (v GemVersion) IsPreRelease() bool {
+	for i := range v.segments {
+		if v.segments[i].letters != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseSegments returns the segments before the first letters.
+func (v GemVersion) releaseSegments() []gemSegment {
+	for i := range v.segments {
+		if v.segments[i].letters != "" {
+			return v.segments[:i]
+		}
+	}
+	return v.segments
+}
+
+// Release returns the version without any pre-release, such as "1.0" for "1.0.b1".
+func (v GemVersion) Release() GemVersion {
+	if !v.IsPreRelease() {
+		return v
+	}
+	return fromGemSegments(v.releaseSegments())
+}
+
+// Bump returns the version after this one, up to which "~>" is satisfied,
+// which is the release without its last segment and the then last one incremented:
+// "3" for "2.2", "2.3" for "2.2.0", and "2" for "1".
+func (v GemVersion) Bump() GemVersion {
+	segments := append([]gemSegment(nil), v.releaseSegments()...)
+	if len(segments) > 1 {
+		segments = segments[:len(segments)-1]
+	}
+	if len(segments) == 0 {
+		return newGemVersion("1")
+	}
+	last := &segments[len(segments)-1]
+	last.number = incrementDecimal(last.number)
+	return fromGemSegments(segments)
+}
+
+// incrementDecimal returns the number one greater than the given one.
+func incrementDecimal(str string) string {
+	digits := []byte(str)
+	i := len(digits) - 1
+	for ; i >= 0 && digits[i] == '9'; i-- {
+		digits[i] = '0'
+	}
+	if i < 0 {
+		return "1" + string(digits)
+	}
+	digits[i]++
+	return string(digits)
+}
+
+// canonicalSegments drops trailing zeroes, both of the release and any pre-release.
+func (v GemVersion) canonicalSegments() []gemSegment {
+	release := v.releaseSegments()
+	pre := v.segments[len(release):]
+	trim := func(segments []gemSegment) []gemSegment {
+		for len(segments) > 0 && segments[len(segments)-1].number == "0" {
+			segments = segments[:len(segments)-1]
+		}
+		return segments
+	}
+	return append(append([]gemSegment(nil), trim(release)...), trim(pre)...)
+}
+
+// Compare returns the signum of the difference between v and o, as RubyGems orders them:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// Segments are compared one by one, numbers as such and letters as strings,
+// with letters below any number, and missing ones being zero.
+// Therefore 1.0.a < 1.0.b1 < 1.0.rc1 < 1.0 = 1.0.0 < 1.0.1.
+func (v GemVersion) Compare(o GemVersion) int {
+	a, b := v.canonicalSegments(), o.canonicalSegments()
+	zero := gemSegment{number: "0"}
+	for i := 0; i < len(a) || i < len(b); i++ {
+		x, y := zero, zero
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		switch {
+		case x.letters != "" && y.letters != "":
+			if c := strings.Compare(x.letters, y.letters); c != 0 {
+				return c
+			}
+		case x.letters != "":
+			return -1
+		case y.letters != "":
+			return 1
+		default:
+			if c := compareDecimals(x.number, y.number); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// Less is a convenience function for sorting.
+func (v *GemVersion) Less(o *GemVersion) bool {
+	return v.Compare(*o) < 0
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v GemVersion) MarshalJSON() ([]byte, error) {
+	return quoteJSON(v.str), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v GemVersion) MarshalText() ([]byte, error) {
+	return []byte(v.str), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *GemVersion) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, v.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *GemVersion) UnmarshalText(b []byte) error {
+	gv, err := NewGemVersion(b)
+	if err != nil {
+		return err
+	}
+	*v = gv
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *GemVersion) Scan(src interface{}) error {
+	return scanText(src, v.UnmarshalText, errInvalidGemType)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (v GemVersion) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// GemVersionPtrs represents an array with elements derived from GemVersion.
+// Use it to sort them, in the order RubyGems does.
+type GemVersionPtrs []*GemVersion
+
+// Len implements the sort.Interface.
+func (p GemVersionPtrs) Len() int {
+	return len(p)
+}
+
+// Swap implements the sort.Interface.
+func (p GemVersionPtrs) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements the sort.Interface.
+func (p GemVersionPtrs) Less(i, j int) bool {
+	if p[i] == nil {
+		return false
+	} else if p[j] == nil {
+		return true
+	}
+	return p[i].Less(p[j])
+}
+
+// Sort reorders the pointers so that the GemVersions appear in ascending order.
+// Any nil pointers go last.
+func (p GemVersionPtrs) Sort() {
+	sort.Stable(p)
+}
+
+// gemOperators in the order they are tried, the longer before any shorter one.
+var gemOperators = [...]string{"~>", "!=", ">=", "<=", "=", ">", "<"}
+
+// gemCondition is one of the comma-separated parts of a GemRequirement, such as "~> 2.2".
+type gemCondition struct {
+	operator string
+	version  GemVersion
+}
+
+// GemRequirement is a set of conditions such as "~> 2.2, != 2.2.1",
+// which a GemVersion must all meet.
+type GemRequirement struct {
+	conditions []gemCondition
+}
+
+// NewGemRequirement reads conditions separated by commas, each of which is an optional operator
+// and a version, as RubyGems' Gem::Requirement does. The operator is one of "=", which is the default,
+// "!=", ">", "<", ">=", "<=", and the pessimistic "~>", which allows the last given segment to increase:
+// "~> 2.2" means ">= 2.2, < 3", and "~> 2.2.0" means ">= 2.2.0, < 2.3".
+//
+// The empty string is read as ">= 0", which any version meets.
+func NewGemRequirement(str []byte) (GemRequirement, error) {
+	if len(bytes.TrimSpace(str)) == 0 {
+		return GemRequirement{conditions: []gemCondition{{operator: ">=", version: newGemVersion("0")}}}, nil
+	}
+	var r GemRequirement
+	var offset int
+	for _, part := range bytes.Split(str, []byte(",")) {
+		c := gemCondition{operator: "="}
+		idx := skipSpace(part, 0)
+		for _, operator := range gemOperators {
+			if bytes.HasPrefix(part[idx:], []byte(operator)) {
+				c.operator = operator
+				idx += len(operator)
+				break
+			}
+		}
+		if len(bytes.TrimSpace(part[idx:])) == 0 {
+			return GemRequirement{}, newParseError(errInvalidGemRequirement, str, offset+len(part), "a version")
+		}
+		v, err := NewGemVersion(part[idx:])
+		if err != nil {
+			return GemRequirement{}, atOffset(err, str, offset+idx)
+		}
+		c.version = v
+		r.conditions = append(r.conditions, c)
+		offset += len(part) + 1
+	}
+	return r, nil
+}
+
+// ParseGemRequirement is NewGemRequirement for strings.
+func ParseGemRequirement(str string) (GemRequirement, error) {
+	return NewGemRequirement([]byte(str))
+}
+
+// isMetBy implements the operators of Gem::Requirement.
+func (c gemCondition) isMetBy(v GemVersion) bool {
+	switch cmp := v.Compare(c.version); c.operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case "~>":
+		return cmp >= 0 && v.Release().Compare(c.version.Bump()) < 0
+	}
+	return false
+}
+
+// Contains returns true if the GemVersion meets every condition,
+// as Gem::Requirement's satisfied_by? does.
+//
+// If in doubt use IsSatisfiedBy.
+func (r GemRequirement) Contains(v GemVersion) bool {
+	for i := range r.conditions {
+		if !r.conditions[i].isMetBy(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSatisfiedBy works like Contains,
+// but rejects pre-releases unless any condition has one, such as ">= 1.0.a".
+//
+// This is how RubyGems and Bundler pick a version, unless told to consider pre-releases.
+func (r GemRequirement) IsSatisfiedBy(v GemVersion) bool {
+	if !r.Contains(v) {
+		return false
+	}
+	if !v.IsPreRelease() {
+		return true
+	}
+	for i := range r.conditions {
+		if r.conditions[i].version.IsPreRelease() {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the GemRequirement as RubyGems writes it, such as "~> 2.2, != 2.2.1".
+func (r GemRequirement) String() string {
+	conditions := make([]string, len(r.conditions))
+	for i, c := range r.conditions {
+		conditions[i] = c.operator + " " + c.version.String()
+	}
+	return strings.Join(conditions, ", ")
+}
@@ -0,0 +1,247 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParseGem(str string) GemVersion {
+	v, err := ParseGem(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestNewGemVersion(t *testing.T) {
+	Convey("NewGemVersion reads…", t, FailureContinues, func() {
+		for str, written := range map[string]string{
+			"1.0":        "1.0",
+			" 1.0\n":     "1.0",
+			"":           "0",
+			"1.0.a":      "1.0.a",
+			"1.0.0.rc1":  "1.0.0.rc1",
+			"1.0x":       "1.0x",
+			"1.0-rc1":    "1.0.pre.rc1",
+			"1.0-a-b.c":  "1.0.pre.a.pre.b.c",
+			"20.1.01.Z9": "20.1.01.Z9",
+		} {
+			Convey(str, func() {
+				v, err := ParseGem(str)
+				So(err, ShouldBeNil)
+				So(v.String(), ShouldEqual, written)
+			})
+		}
+	})
+
+	Convey("NewGemVersion rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"a":      0,
+			"1..2":   2,
+			"1.0.":   4,
+			"1.0-":   4,
+			"1_0":    1,
+			"1.0 1":  3,
+			"1.0-a_": 5,
+			" .1":    1,
+		} {
+			Convey(str, func() {
+				_, err := ParseGem(str)
+				So(errors.Is(err, errInvalidGemVersion), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+
+	Convey("Pre-releases are those with letters", t, func() {
+		So(mustParseGem("1.0.a").IsPreRelease(), ShouldBeTrue)
+		So(mustParseGem("1.0.0.rc1").IsPreRelease(), ShouldBeTrue)
+		So(mustParseGem("1.0-1").IsPreRelease(), ShouldBeTrue)
+		So(mustParseGem("1.0.1").IsPreRelease(), ShouldBeFalse)
+	})
+
+	Convey("Release drops the pre-release", t, func() {
+		So(mustParseGem("1.0.b1").Release().String(), ShouldEqual, "1.0")
+		So(mustParseGem("1.0.a.2").Release().String(), ShouldEqual, "1.0")
+		So(mustParseGem("1.0.2").Release().String(), ShouldEqual, "1.0.2")
+	})
+
+	Convey("Bump increments the second-to-last segment", t, func() {
+		for str, bumped := range map[string]string{
+			"5.2.4":   "5.3",
+			"5.2.4.a": "5.3",
+			"5.2.a.4": "6",
+			"2.2":     "3",
+			"2.2.0":   "2.3",
+			"5":       "6",
+			"1.99":    "2",
+			"9.9.9":   "9.10",
+		} {
+			Convey(str, func() {
+				So(mustParseGem(str).Bump().String(), ShouldEqual, bumped)
+			})
+		}
+	})
+}
+
+func TestGemVersionOrder(t *testing.T) {
+	// Mostly from the tests of RubyGems.
+	ordered := []string{
+		"0.beta.1", "0.0.beta.2", "0.0.0", "1.0.a", "1.0.b1", "1.0.pre.rc1", "1.0.rc1",
+		"1.0", "1.8.2.a", "1.8.2.a9", "1.8.2.a10", "1.8.2.b", "1.8.2", "5.a", "5.0.0.rc2",
+		"5.x", "5.0.0.1", "10.0", "99999999999999999999.0",
+	}
+
+	Convey("GemVersions are ordered as by RubyGems", t, func() {
+		for i := 0; i+1 < len(ordered); i++ {
+			a, b := mustParseGem(ordered[i]), mustParseGem(ordered[i+1])
+			So(a.Compare(b), ShouldEqual, -1)
+			So(b.Compare(a), ShouldEqual, 1)
+		}
+		for a, b := range map[string]string{
+			"1.0":        "1.0.0",
+			"":           "0",
+			"0.beta.1":   "0.0.beta.1",
+			"1.0.rc.0":   "1.0.0.rc",
+			"01.0":       "1",
+			"1.0.0-rc.1": "1.0.0.pre.rc.1",
+		} {
+			So(mustParseGem(a).Compare(mustParseGem(b)), ShouldEqual, 0)
+		}
+		So(mustParseGem("1.0.A").Compare(mustParseGem("1.0.a")), ShouldEqual, -1)
+
+		Convey("also by GemVersionPtrs, nil last", func() {
+			ptrs := GemVersionPtrs{nil}
+			for i := len(ordered) - 1; i >= 0; i-- {
+				v := mustParseGem(ordered[i])
+				ptrs = append(ptrs, &v)
+			}
+			ptrs.Sort()
+			for i := range ordered {
+				So(ptrs[i].String(), ShouldEqual, ordered[i])
+			}
+			So(ptrs[len(ordered)], ShouldBeNil)
+		})
+	})
+
+	Convey("GemVersion is written as RubyGems does…", t, FailureContinues, func() {
+		Convey("with the hyphen of a pre-release as .pre.", func() {
+			v := mustParseGem("1.0.0-rc1")
+			out, err := json.Marshal(v)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `"1.0.0.pre.rc1"`)
+			var back GemVersion
+			So(json.Unmarshal(out, &back), ShouldBeNil)
+			So(back.Compare(v), ShouldEqual, 0)
+		})
+
+		Convey("with the empty string, and whitespace, as 0", func() {
+			var v GemVersion
+			So(json.Unmarshal([]byte(`""`), &v), ShouldBeNil)
+			So(v.String(), ShouldEqual, "0")
+			So(json.Unmarshal([]byte(`" 1.0\n"`), &v), ShouldBeNil)
+			text, err := v.MarshalText()
+			So(err, ShouldBeNil)
+			So(string(text), ShouldEqual, "1.0")
+		})
+	})
+
+	Convey("GemVersion is scanned from databases", t, func() {
+		v := mustParseGem("20.1.01.Z9")
+		val, _ := v.Value()
+		So(val, ShouldEqual, "20.1.01.Z9")
+		var back GemVersion
+		So(back.Scan(val), ShouldBeNil)
+		So(back, ShouldResemble, v)
+		So(errors.Is(back.Scan([]byte("1..2")), errInvalidGemVersion), ShouldBeTrue)
+		So(back.Scan(3.14), ShouldEqual, errInvalidGemType)
+	})
+}
+
+func TestGemRequirement(t *testing.T) {
+	Convey("GemRequirement contains…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"":            {"0": true, "1.0": true, "1.0.a": true},
+			"1.0":         {"1.0": true, "1.0.0": true, "1.0.1": false, "1.0.a": false},
+			"= 1.0":       {"1.0": true, "0.9": false},
+			"!= 1.5":      {"1.5": false, "1.5.0": false, "1.5.1": true, "1.4": true},
+			"> 1.0":       {"1.0": false, "1.0.1": true, "1.1.a": true},
+			"< 1.0":       {"0.9": true, "1.0.a": true, "1.0": false},
+			">= 1.0":      {"1.0": true, "0.9": false},
+			"<= 1.0":      {"1.0": true, "1.0.1": false},
+			"~> 2.2":      {"2.2": true, "2.9.9": true, "3.0": false, "2.1": false, "3.0.a": false},
+			"~> 2.2.0":    {"2.2.0": true, "2.2.9": true, "2.3": false, "2.1.9": false},
+			"~> 1.4.4":    {"1.4.5": true, "1.5.0": false},
+			"~> 0.0.1":    {"0.0.2": true, "0.1.1": false},
+			"~> 5":        {"5.9": true, "6": false, "4.9": false},
+			"~> 1.0.a":    {"1.0.a": true, "1.0.b": true, "1.0": true, "1.9": true, "2.0.a": false},
+			">= 1.0, < 3": {"1.0": true, "2.9": true, "3": false, "0.9": false},
+			" >=1.0 ,<3 ": {"2.0": true, "3.0": false},
+		} {
+			r, err := ParseGemRequirement(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(r.Contains(mustParseGem(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("IsSatisfiedBy excludes pre-releases…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"":              {"1.0": true, "1.0.a": false},
+			"~> 1.0":        {"1.0.1.pre": false, "1.0.1": true},
+			">= 1.0.a":      {"1.0.b": true, "2.0.rc1": true},
+			">= 1, < 2.0.a": {"1.5.a": true},
+		} {
+			r, err := ParseGemRequirement(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(r.IsSatisfiedBy(mustParseGem(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("GemRequirement is written as by RubyGems", t, func() {
+		for str, written := range map[string]string{
+			"":               ">= 0",
+			"1.0":            "= 1.0",
+			"~>2.2,!= 2.2.1": "~> 2.2, != 2.2.1",
+			" >= 1.0-rc1 ":   ">= 1.0.pre.rc1",
+		} {
+			r, err := ParseGemRequirement(str)
+			So(err, ShouldBeNil)
+			So(r.String(), ShouldEqual, written)
+		}
+	})
+
+	Convey("NewGemRequirement rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"~>":       2,
+			">= 1.0, ": 8,
+			"=> 1":     1,
+			">= 1.0.":  7,
+			"1.0, ~>x": 7,
+		} {
+			Convey(str, func() {
+				_, err := ParseGemRequirement(str)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Input, ShouldEqual, str)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
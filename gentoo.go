@@ -0,0 +1,205 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"strings"
+)
+
+const errInvalidGentooVersion InvalidStringValue = "Given string does not resemble a Gentoo version"
+
+// gentooSuffixes are the suffixes of the Package Manager Specification, in ascending order.
+var gentooSuffixes = [...]string{"alpha", "beta", "pre", "rc", "p"}
+
+// gentooSuffix is one of the chained suffixes, such as "_pre1" in "1.0_alpha4_pre1".
+type gentooSuffix struct {
+	kind   int    // Index into gentooSuffixes, or apkSuffixes.
+	number string // Digits, if any.
+}
+
+// GentooVersion is a version as the Package Manager Specification (PMS) of Gentoo Linux
+// defines it, such as "1.2.3b_alpha4_pre1_p2-r7".
+//
+// Unlike Version this has any number of numeric components, a single letter,
+// any number of suffixes _alpha, _beta, _pre, _rc and _p, and a revision "-rN".
+// Numbers can be of arbitrary length.
+type GentooVersion struct {
+	str      string
+	numbers  []string
+	letter   byte
+	suffixes []gentooSuffix
+	revision string
+}
+
+// NewGentooVersion reads a version in the notation of PMS §3.2, without the package name.
+func NewGentooVersion(str []byte) (GentooVersion, error) {
+	v := GentooVersion{str: string(str)}
+	var idx int
+	for {
+		n := countDigits(str[idx:])
+		if n == 0 {
+			return GentooVersion{}, newParseError(errInvalidGentooVersion, str, idx, "a digit")
+		}
+		v.numbers = append(v.numbers, v.str[idx:idx+n])
+		idx += n
+		if idx >= len(str) || str[idx] != '.' {
+			break
+		}
+		idx++
+	}
+	if idx < len(str) && isSmallLetter(str[idx]) {
+		v.letter = str[idx]
+		idx++
+	}
+
+	for idx < len(str) && str[idx] == '_' {
+		idx++
+		kind := -1
+		for i, suffix := range gentooSuffixes {
+			if strings.HasPrefix(v.str[idx:], suffix) {
+				kind = i
+				break
+			}
+		}
+		if kind < 0 {
+			return GentooVersion{}, newParseError(errInvalidGentooVersion, str, idx, "alpha, beta, pre, rc, or p")
+		}
+		idx += len(gentooSuffixes[kind])
+		n := countDigits(str[idx:])
+		v.suffixes = append(v.suffixes, gentooSuffix{kind: kind, number: v.str[idx : idx+n]})
+		idx += n
+	}
+
+	if idx+1 < len(str) && str[idx] == '-' && str[idx+1] == 'r' {
+		idx += 2
+		n := countDigits(str[idx:])
+		if n == 0 {
+			return GentooVersion{}, newParseError(errInvalidGentooVersion, str, idx, "a digit")
+		}
+		v.revision = v.str[idx : idx+n]
+		idx += n
+	}
+
+	if idx < len(str) {
+		return GentooVersion{}, newParseError(errInvalidGentooVersion, str, idx, "'_', \"-r\", or the end")
+	}
+	return v, nil
+}
+
+// ParseGentoo is NewGentooVersion for strings.
+func ParseGentoo(str string) (GentooVersion, error) {
+	return NewGentooVersion([]byte(str))
+}
+
+// String returns the version as it has been read.
+func (v GentooVersion) String() string {
+	return v.str
+}
+
+// Less is true if v is lower than o by PMS §3.3.
+func (v GentooVersion) Less(o GentooVersion) bool {
+	return v.Compare(o) < 0
+}
+
+// Compare returns the signum of the difference between v and o,
+// by the algorithms in PMS §3.3:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// Versions can be equal without being the same, such as 1.0 and 1.00, or 1.0 and 1.0-r0.
+func (v GentooVersion) Compare(o GentooVersion) int {
+	if len(v.numbers) == 0 || len(o.numbers) == 0 { // The zero value is the lowest.
+		return signum(len(v.numbers) - len(o.numbers))
+	}
+
+	// Algorithms 3.2 and 3.3
+	if c := compareGentooNumbers(v.numbers, o.numbers); c != 0 {
+		return c
+	}
+
+	// Algorithm 3.4: No letter is lower than any.
+	if c := signum(int(v.letter) - int(o.letter)); c != 0 {
+		return c
+	}
+
+	// Algorithm 3.5 and 3.6
+	if c := compareGentooSuffixes(v.suffixes, o.suffixes, len(gentooSuffixes)-1); c != 0 {
+		return c
+	}
+
+	// Algorithm 3.7
+	return compareDecimals(v.revision, o.revision)
+}
+
+// compareGentooNumbers compares the numeric components by Algorithms 3.2 and 3.3 of PMS.
+func compareGentooNumbers(a, b []string) int {
+	// Algorithm 3.2: The first component is compared as integer.
+	if c := compareDecimals(a[0], b[0]); c != 0 {
+		return c
+	}
+	// Algorithm 3.3: Any other is compared as string if it has a leading zero.
+	for i := 1; i < len(a) && i < len(b); i++ {
+		var c int
+		if a[i][0] == '0' || b[i][0] == '0' {
+			c = strings.Compare(strings.TrimRight(a[i], "0"), strings.TrimRight(b[i], "0"))
+		} else {
+			c = compareDecimals(a[i], b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return signum(len(a) - len(b))
+}
+
+// compareGentooSuffixes compares chains of suffixes by Algorithms 3.5 and 3.6 of PMS:
+// by their kind, then number. Of two chains that are otherwise equal the longer one is greater
+// if its next suffix is of kind firstPost or above, else lower.
+func compareGentooSuffixes(a, b []gentooSuffix, firstPost int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].kind != b[i].kind {
+			return signum(a[i].kind - b[i].kind)
+		}
+		if c := compareDecimals(a[i].number, b[i].number); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a) > len(b):
+		if a[len(b)].kind >= firstPost {
+			return 1
+		}
+		return -1
+	case len(a) < len(b):
+		if b[len(a)].kind >= firstPost {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// countDigits returns how many leading bytes of str are digits.
+func countDigits(str []byte) int {
+	for n, ch := range str {
+		if !isNumeric(ch) {
+			return n
+		}
+	}
+	return len(str)
+}
+
+// compareDecimals compares two unsigned integers of arbitrary length,
+// given as digits, and returns the signum of their difference.
+// The empty string is zero.
+func compareDecimals(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return signum(len(a) - len(b))
+	}
+	return strings.Compare(a, b)
+}
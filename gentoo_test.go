@@ -0,0 +1,153 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParseGentoo(str string) GentooVersion {
+	v, err := ParseGentoo(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestNewGentooVersion(t *testing.T) {
+	Convey("NewGentooVersion works with…", t, FailureContinues, func() {
+		for _, str := range []string{
+			"0", "1.0a", "1.2.3b_alpha4_pre1_p2-r7", "2019.09.17", "1_p", "1.0-r0",
+			"3.0_rc_p20190101", "99999999999999999999.1", "1.0_pre_pre_pre",
+		} {
+			Convey(str, func() {
+				v, err := ParseGentoo(str)
+				So(err, ShouldBeNil)
+				So(v.String(), ShouldEqual, str)
+			})
+		}
+
+		Convey("all of Gentoo's portage tree", func() {
+			for _, line := range VersionsFromGentoo {
+				_, err := NewGentooVersion(line)
+				if string(line) == "11 13:09 portage/skel" { // Not a version, an artifact of its extraction.
+					So(err, ShouldNotBeNil)
+					continue
+				}
+				if err != nil {
+					So(err, ShouldBeNil)
+				}
+			}
+		})
+	})
+
+	Convey("NewGentooVersion rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":            0,
+			"v1.0":        0,
+			"1.":          2,
+			"1..0":        2,
+			"1.0ab":       4,
+			"1.0_gamma":   4,
+			"1.0-r":       5,
+			"1.0-1":       3,
+			"1.0_p1-r1_p": 9,
+			"1.0A":        3,
+			"1.0_p1a":     6,
+			"1.0 ":        3,
+		} {
+			Convey(str, func() {
+				_, err := ParseGentoo(str)
+				So(errors.Is(err, errInvalidGentooVersion), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
+
+func TestGentooVersionOrder(t *testing.T) {
+	Convey("PMS ordering, as by Portage's vercmp, works with…", t, FailureContinues, func() {
+		for _, pair := range [][2]string{
+			{"4.0", "5.0"},
+			{"5", "5.0"},
+			{"1.0_pre2", "1.0_p2"},
+			{"1.0_alpha2", "1.0_p2"},
+			{"1.0_alpha1", "1.0_beta1"},
+			{"1.0_beta3", "1.0_rc3"},
+			{"1.001000000000000000001", "1.001000000000000000002"},
+			{"1.00100000000", "1.0010000000000000001"},
+			{"999999999999999999999999999998", "999999999999999999999999999999"},
+			{"1.01", "1.1"},
+			{"1.0-r0", "1.0-r1"},
+			{"1.0", "1.0-r1"},
+			{"1.0", "1.0.0"},
+			{"1.0b", "1.0.0"},
+			{"1_p1", "1b_p1"},
+			{"1", "1b"},
+			{"1.1", "1.1b"},
+			{"12.2b", "12.2.5"},
+			{"1.0_alpha", "1.0_alpha1"},
+			{"1.0_alpha_p", "1.0_alpha1"},
+			{"1.0_alpha", "1.0_alpha_p"},
+			{"1.0_rc9", "1.0"},
+			{"1.0", "1.0_p"},
+			{"1.0-r9", "1.0_p1"},
+			{"1.0_p1", "1.0a"},
+			{"1.0_alpha_rc", "1.0_alpha"},
+			{"1.2.3b_alpha4_pre1_p2-r7", "1.2.3b_alpha4_pre1_p2-r8"},
+			{"1.2.3b_alpha4_pre1-r8", "1.2.3b_alpha4_pre1_p2"},
+		} {
+			Convey(pair[0]+" < "+pair[1], func() {
+				a, b := mustParseGentoo(pair[0]), mustParseGentoo(pair[1])
+				So(a.Compare(b), ShouldEqual, -1)
+				So(b.Compare(a), ShouldEqual, 1)
+				So(a.Less(b), ShouldBeTrue)
+				So(b.Less(a), ShouldBeFalse)
+			})
+		}
+
+		for _, pair := range [][2]string{
+			{"4.0", "4.0"},
+			{"1.0-r0", "1.0"},
+			{"1.0", "1.00"},
+			{"1.01", "1.010"},
+			{"01.0", "1.0"},
+			{"1_p", "1_p0"},
+		} {
+			Convey(pair[0]+" = "+pair[1], func() {
+				a, b := mustParseGentoo(pair[0]), mustParseGentoo(pair[1])
+				So(a.Compare(b), ShouldEqual, 0)
+				So(b.Compare(a), ShouldEqual, 0)
+			})
+		}
+	})
+
+	Convey("Ordering all of Gentoo's portage tree is consistent", t, func() {
+		versions := make([]GentooVersion, 0, len(VersionsFromGentoo))
+		for _, line := range VersionsFromGentoo {
+			if v, err := NewGentooVersion(line); err == nil {
+				versions = append(versions, v)
+			}
+		}
+		sort.SliceStable(versions, func(i, j int) bool { return versions[i].Less(versions[j]) })
+
+		inconsistencies := 0
+		for _, distance := range []int{1, 7, 997} { // Transitivity, by sampling.
+			for i := distance; i < len(versions); i++ {
+				a, b := versions[i-distance], versions[i]
+				if a.Compare(b) > 0 || a.Compare(b) != -b.Compare(a) {
+					inconsistencies++
+				}
+			}
+		}
+		So(inconsistencies, ShouldEqual, 0)
+	})
+}
@@ -20,13 +20,14 @@ package semver
 //   common: 0
 //
 // Thus, if you don't want any pre-release options, set minReleaseType to 0.
+// Versions with pre-release identifiers beyond those get no suggestions.
 //
 // Deprecated: This is a legacy method for the Caddyserver's build infrastructure.
 // Do not rely on it, they are free to~ and can change it anytime.
 func (t Version) NextVersions(minReleaseType int, numberedPre bool) []*Version {
 	var next []*Version
 
-	if minReleaseType < alpha || minReleaseType > common {
+	if minReleaseType < alpha || minReleaseType > common || t.preRelease != "" {
 		return next
 	}
 
@@ -0,0 +1,544 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Errors that are thrown when reading a MavenVersion or MavenRange.
+const (
+	errInvalidMavenVersion InvalidStringValue = "Given string does not resemble a Maven version"
+	errInvalidMavenType    InvalidStringValue = "Cannot read this type into a MavenVersion"
+	errInvalidMavenRange   InvalidStringValue = "Given string does not resemble a range of Maven versions"
+)
+
+// mavenQualifiers are the well-known qualifiers in ascending order, with the release as empty string.
+// Any other qualifier sorts after them all, by its letters.
+var mavenQualifiers = [...]string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// mavenAliases are qualifiers that are spelled differently, but mean the same.
+var mavenAliases = map[string]string{"ga": "", "final": "", "release": "", "cr": "rc"}
+
+// Kinds of mavenItem. The zero value is an empty list.
+const (
+	mavenList = iota
+	mavenNumber
+	mavenQualifier
+)
+
+// mavenItem is a number, a qualifier, or a list of them, which is what a hyphen starts.
+type mavenItem struct {
+	kind  int
+	value string // Digits without leading zeroes, or the qualifier in lower case.
+	list  []*mavenItem
+}
+
+// MavenVersion is a version of an artifact in a Maven repository, such as "1.0-SNAPSHOT", "1.0.Final" or "1.0-M2",
+// ordered as Maven's ComparableVersion does since Maven 3.8.
+//
+// Any number of numbers and qualifiers are separated by dots and hyphens,
+// or by the transition between digits and letters.
+type MavenVersion struct {
+	str   string
+	items mavenItem
+}
+
+// NewMavenVersion reads a version of an artifact in a Maven repository.
+//
+// Like Maven this accepts just about anything, but for the empty string, whitespace,
+// and any of "[](),", which would be ambiguous in a MavenRange.
+func NewMavenVersion(str []byte) (MavenVersion, error) {
+	if len(str) == 0 {
+		return MavenVersion{}, newParseError(errInvalidMavenVersion, str, 0, "a version")
+	}
+	if idx := bytes.IndexFunc(str, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("[](),", r)
+	}); idx >= 0 {
+		return MavenVersion{}, newParseError(errInvalidMavenVersion, str, idx, "no whitespace or any of [](),")
+	}
+
+	v := MavenVersion{str: string(str)}
+	lower := strings.ToLower(v.str)
+	list := &v.items
+	stack := []*mavenItem{list}
+	startNewList := func() {
+		next := &mavenItem{kind: mavenList}
+		list.list = append(list.list, next)
+		list = next
+		stack = append(stack, list)
+	}
+
+	var isDigit bool
+	var start int
+	for i := 0; i < len(lower); i++ {
+		switch ch := lower[i]; {
+		case ch == '.' || ch == '-':
+			if i == start {
+				list.list = append(list.list, &mavenItem{kind: mavenNumber, value: "0"})
+			} else {
+				list.list = append(list.list, newMavenItem(lower[start:i], isDigit, false))
+			}
+			start = i + 1
+			if ch == '-' {
+				startNewList()
+			}
+		case isNumeric(ch):
+			if !isDigit && i > start { // "1.0.0.X1" is read as "1.0.0.X-1".
+				list.list = append(list.list, newMavenItem(lower[start:i], false, true))
+				start = i
+				startNewList()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				list.list = append(list.list, newMavenItem(lower[start:i], true, false))
+				start = i
+				startNewList()
+			}
+			isDigit = false
+		}
+	}
+	if len(lower) > start {
+		list.list = append(list.list, newMavenItem(lower[start:], isDigit, false))
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+	return v, nil
+}
+
+// ParseMaven is NewMavenVersion for strings.
+func ParseMaven(str string) (MavenVersion, error) {
+	return NewMavenVersion([]byte(str))
+}
+
+// newMavenItem returns a number or qualifier.
+// A qualifier of one letter is short for a longer one if a number follows, such as "a1" for "alpha-1".
+func newMavenItem(str string, isDigit, followedByDigit bool) *mavenItem {
+	if isDigit {
+		if str = strings.TrimLeft(str, "0"); str == "" {
+			str = "0"
+		}
+		return &mavenItem{kind: mavenNumber, value: str}
+	}
+	if followedByDigit {
+		switch str {
+		case "a":
+			str = "alpha"
+		case "b":
+			str = "beta"
+		case "m":
+			str = "milestone"
+		}
+	}
+	if alias, ok := mavenAliases[str]; ok {
+		str = alias
+	}
+	return &mavenItem{kind: mavenQualifier, value: str}
+}
+
+// isNull is true for items which are the same as their absence: 0, the release, and the empty list.
+func (it *mavenItem) isNull() bool {
+	switch it.kind {
+	case mavenNumber:
+		return it.value == "0"
+	case mavenQualifier:
+		return it.value == ""
+	}
+	return len(it.list) == 0
+}
+
+// normalize removes trailing items that are null, but not past anything but a list.
+func (it *mavenItem) normalize() {
+	for i := len(it.list) - 1; i >= 0; i-- {
+		if it.list[i].isNull() {
+			it.list = append(it.list[:i], it.list[i+1:]...)
+		} else if it.list[i].kind != mavenList {
+			break
+		}
+	}
+}
+
+// mavenQualifierOrder returns what qualifiers are ordered by as strings.
+func mavenQualifierOrder(qualifier string) string {
+	for i, q := range mavenQualifiers {
+		if q == qualifier {
+			return strconv.Itoa(i)
+		}
+	}
+	return strconv.Itoa(len(mavenQualifiers)) + "-" + qualifier
+}
+
+// compare returns the signum of the difference between it and o, which is nil if absent.
+func (it *mavenItem) compare(o *mavenItem) int {
+	switch it.kind {
+	case mavenNumber:
+		switch {
+		case o == nil:
+			if it.value == "0" {
+				return 0
+			}
+			return 1
+		case o.kind == mavenNumber:
+			return compareDecimals(it.value, o.value)
+		}
+		return 1 // 1.1 > 1-sp and 1.1 > 1-1
+	case mavenQualifier:
+		switch {
+		case o == nil:
+			return strings.Compare(mavenQualifierOrder(it.value), mavenQualifierOrder(""))
+		case o.kind == mavenQualifier:
+			return strings.Compare(mavenQualifierOrder(it.value), mavenQualifierOrder(o.value))
+		}
+		return -1 // 1.any < 1.1 and 1.any < 1-1
+	}
+
+	switch {
+	case o == nil: // All items count, as since Maven 3.8 (MNG-6964), hence 1-0.1 > 1.
+		for _, item := range it.list {
+			if c := item.compare(nil); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case o.kind == mavenNumber:
+		return -1
+	case o.kind == mavenQualifier:
+		return 1
+	}
+	for i := 0; i < len(it.list) || i < len(o.list); i++ {
+		var c int
+		switch {
+		case i >= len(it.list):
+			c = -o.list[i].compare(nil)
+		case i >= len(o.list):
+			c = it.list[i].compare(nil)
+		default:
+			c = it.list[i].compare(o.list[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// appendTo writes the canonical representation of the item to target.
+func (it *mavenItem) appendTo(target []byte) []byte {
+	if it.kind != mavenList {
+		return append(target, it.value...)
+	}
+	start := len(target)
+	for _, item := range it.list {
+		if len(target) > start {
+			if item.kind == mavenList {
+				target = append(target, '-')
+			} else {
+				target = append(target, '.')
+			}
+		}
+		target = item.appendTo(target)
+	}
+	return target
+}
+
+// Canonical returns the representation of v in which equal MavenVersions are the same,
+// such as "1-snapshot" for "1.0-SNAPSHOT", or "1-alpha-2" for "1.0.0-a2".
+func (v MavenVersion) Canonical() string {
+	return string(v.items.appendTo(nil))
+}
+
+// Compare returns the signum of the difference between v and o, as Maven orders them:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// Numbers are compared as such, and qualifiers in this order, with any unknown one last:
+// alpha (or a), beta (b), milestone (m), rc (cr), snapshot, the release (ga, final), and sp.
+// Numbers sort after qualifiers, and a trailing 0 or release can be omitted:
+// 1.0-alpha1 < 1.0-SNAPSHOT < 1.0 = 1.0.0.Final < 1.0-sp1 < 1.0.1.
+func (v MavenVersion) Compare(o MavenVersion) int {
+	return v.items.compare(&o.items)
+}
+
+// Less is a convenience function for sorting.
+func (v *MavenVersion) Less(o *MavenVersion) bool {
+	return v.Compare(*o) < 0
+}
+
+// String returns the version as it has been read.
+// Use Canonical for a representation which is the same for equal MavenVersions.
+func (v MavenVersion) String() string {
+	return v.str
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v MavenVersion) MarshalJSON() ([]byte, error) {
+	return quoteJSON(v.str), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v MavenVersion) MarshalText() ([]byte, error) {
+	return []byte(v.str), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *MavenVersion) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, v.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *MavenVersion) UnmarshalText(b []byte) error {
+	mv, err := NewMavenVersion(b)
+	if err != nil {
+		return err
+	}
+	*v = mv
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *MavenVersion) Scan(src interface{}) error {
+	return scanText(src, v.UnmarshalText, errInvalidMavenType)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (v MavenVersion) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// MavenVersionPtrs represents an array with elements derived from MavenVersion.
+// Use it to sort them, in the order Maven does.
+type MavenVersionPtrs []*MavenVersion
+
+// Len implements the sort.Interface.
+func (p MavenVersionPtrs) Len() int {
+	return len(p)
+}
+
+// Swap implements the sort.Interface.
+func (p MavenVersionPtrs) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements the sort.Interface.
+func (p MavenVersionPtrs) Less(i, j int) bool {
+	if p[i] == nil {
+		return false
+	} else if p[j] == nil {
+		return true
+	}
+	return p[i].Less(p[j])
+}
+
+// Sort reorders the pointers so that the MavenVersions appear in ascending order.
+// Any nil pointers go last.
+func (p MavenVersionPtrs) Sort() {
+	sort.Stable(p)
+}
+
+// mavenRestriction is one interval of a MavenRange, such as "[1.0,2.0)".
+type mavenRestriction struct {
+	lower       MavenVersion
+	upper       MavenVersion
+	hasLower    bool
+	equalsLower bool
+	hasUpper    bool
+	equalsUpper bool
+}
+
+// MavenRange is a union of intervals of MavenVersions, such as "(,1.0],[1.2,)",
+// or a sole version which Maven treats as recommendation.
+type MavenRange struct {
+	recommended    MavenVersion
+	hasRecommended bool
+	restrictions   []mavenRestriction
+}
+
+// NewMavenRange reads a range in the notation of Maven:
+// A sole version such as "1.0" is a recommendation, which every version satisfies.
+// Intervals such as "[1.0,2.0)" include a boundary if it's next to a square bracket,
+// and have no boundary on the side without version, such as "[1.2,)".
+// "[1.0]" is just 1.0. Several intervals, in ascending order and without overlap,
+// are separated by commas, and any version that is in one of them is in the MavenRange.
+func NewMavenRange(str []byte) (MavenRange, error) {
+	var r MavenRange
+	idx := skipSpace(str, 0)
+	if idx >= len(str) || (str[idx] != '[' && str[idx] != '(') {
+		end := len(bytes.TrimRightFunc(str, unicode.IsSpace))
+		v, err := NewMavenVersion(str[idx:end])
+		if err != nil {
+			return MavenRange{}, atOffset(err, str, idx)
+		}
+		r.recommended, r.hasRecommended = v, true
+		return r, nil
+	}
+
+	for {
+		end := bytes.IndexAny(str[idx:], ")]")
+		if end < 0 {
+			return MavenRange{}, newParseError(errInvalidMavenRange, str, len(str), "']' or ')'")
+		}
+		end += idx + 1
+		restriction, err := newMavenRestriction(str[idx:end])
+		if err != nil {
+			return MavenRange{}, atOffset(err, str, idx)
+		}
+		if n := len(r.restrictions); n > 0 {
+			preceding := r.restrictions[n-1]
+			if !preceding.hasUpper || !restriction.hasLower || restriction.lower.Compare(preceding.upper) < 0 {
+				return MavenRange{}, newParseError(errInvalidMavenRange, str, idx, "no overlap with the preceding interval")
+			}
+		}
+		r.restrictions = append(r.restrictions, restriction)
+
+		if idx = skipSpace(str, end); idx >= len(str) {
+			return r, nil
+		}
+		if str[idx] != ',' {
+			return MavenRange{}, newParseError(errInvalidMavenRange, str, idx, "',' or the end")
+		}
+		if idx = skipSpace(str, idx+1); idx >= len(str) || (str[idx] != '[' && str[idx] != '(') {
+			return MavenRange{}, newParseError(errInvalidMavenRange, str, idx, "'[' or '('")
+		}
+	}
+}
+
+// ParseMavenRange is NewMavenRange for strings.
+func ParseMavenRange(str string) (MavenRange, error) {
+	return NewMavenRange([]byte(str))
+}
+
+// skipSpace returns the index of the first byte at or after idx which is no whitespace.
+func skipSpace(str []byte, idx int) int {
+	return len(str) - len(bytes.TrimLeftFunc(str[idx:], unicode.IsSpace))
+}
+
+// newMavenRestriction reads an interval, including its brackets.
+func newMavenRestriction(str []byte) (mavenRestriction, error) {
+	r := mavenRestriction{equalsLower: str[0] == '[', equalsUpper: str[len(str)-1] == ']'}
+	comma := bytes.IndexByte(str, ',')
+	if comma < 0 {
+		if !r.equalsLower || !r.equalsUpper {
+			return mavenRestriction{}, newParseError(errInvalidMavenRange, str, 0, "'[' and ']' around a single version")
+		}
+		start := skipSpace(str, 1)
+		v, err := NewMavenVersion(bytes.TrimRightFunc(str[start:len(str)-1], unicode.IsSpace))
+		if err != nil {
+			return mavenRestriction{}, atOffset(err, str, start)
+		}
+		r.lower, r.hasLower, r.upper, r.hasUpper = v, true, v, true
+		return r, nil
+	}
+
+	lowerStart, upperStart := skipSpace(str, 1), skipSpace(str, comma+1)
+	lower := bytes.TrimRightFunc(str[lowerStart:comma], unicode.IsSpace)
+	upper := bytes.TrimRightFunc(str[upperStart:len(str)-1], unicode.IsSpace)
+	if len(lower) > 0 {
+		v, err := NewMavenVersion(lower)
+		if err != nil {
+			return mavenRestriction{}, atOffset(err, str, lowerStart)
+		}
+		r.lower, r.hasLower = v, true
+	}
+	if len(upper) > 0 {
+		v, err := NewMavenVersion(upper)
+		if err != nil {
+			return mavenRestriction{}, atOffset(err, str, upperStart)
+		}
+		r.upper, r.hasUpper = v, true
+	}
+	switch {
+	case bytes.Equal(lower, upper):
+		return mavenRestriction{}, newParseError(errInvalidMavenRange, str, upperStart, "an upper boundary that differs from the lower one")
+	case r.hasLower && r.hasUpper && r.upper.Compare(r.lower) < 0:
+		return mavenRestriction{}, newParseError(errInvalidMavenRange, str, upperStart, "an upper boundary above the lower one")
+	}
+	return r, nil
+}
+
+// contains is true if v is within the interval.
+func (r mavenRestriction) contains(v MavenVersion) bool {
+	if r.hasLower {
+		c := v.Compare(r.lower)
+		if c < 0 || (c == 0 && !r.equalsLower) {
+			return false
+		}
+	}
+	if r.hasUpper {
+		c := v.Compare(r.upper)
+		if c > 0 || (c == 0 && !r.equalsUpper) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the interval in the notation newMavenRestriction reads.
+func (r mavenRestriction) String() string {
+	var b strings.Builder
+	if r.equalsLower {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if r.hasLower {
+		b.WriteString(r.lower.String())
+	}
+	if !r.hasLower || !r.hasUpper || r.lower.str != r.upper.str {
+		b.WriteByte(',')
+		if r.hasUpper {
+			b.WriteString(r.upper.String())
+		}
+	}
+	if r.equalsUpper {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// Recommended returns the sole version of a MavenRange such as "1.0", else nil.
+func (r MavenRange) Recommended() *MavenVersion {
+	if !r.hasRecommended {
+		return nil
+	}
+	return &r.recommended
+}
+
+// Contains returns true if the MavenVersion is within any of the intervals,
+// or if the MavenRange is but a recommendation.
+func (r MavenRange) Contains(v MavenVersion) bool {
+	if r.hasRecommended {
+		return true
+	}
+	for i := range r.restrictions {
+		if r.restrictions[i].contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the MavenRange in the notation NewMavenRange reads.
+func (r MavenRange) String() string {
+	if r.hasRecommended {
+		return r.recommended.String()
+	}
+	intervals := make([]string, len(r.restrictions))
+	for i := range r.restrictions {
+		intervals[i] = r.restrictions[i].String()
+	}
+	return strings.Join(intervals, ",")
+}
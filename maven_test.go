@@ -0,0 +1,204 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParseMaven(str string) MavenVersion {
+	v, err := ParseMaven(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestMavenVersionOrder(t *testing.T) {
+	// These are from the tests of Maven's ComparableVersion.
+	Convey("MavenVersions are ordered as Maven does…", t, FailureContinues, func() {
+		for _, ordered := range [][]string{
+			{
+				"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123", "1-m2", "1-m11",
+				"1-rc", "1-cr2", "1-rc123", "1-SNAPSHOT", "1", "1-sp", "1-sp2", "1-sp123", "1-abc",
+				"1-def", "1-pom-1", "1-1-snapshot", "1-1", "1-2", "1-123",
+			},
+			{
+				"2.0", "2-1", "2.0.a", "2.0.0.a", "2.0.2", "2.0.123", "2.1.0", "2.1-a", "2.1b", "2.1-c",
+				"2.1-1", "2.1.0.1", "2.2", "2.123", "11.a2", "11.a11", "11.b2", "11.b11", "11.m2", "11.m11",
+				"11", "11.a", "11b", "11c", "11m",
+			},
+			{"1.0-alpha1", "1.0-SNAPSHOT", "1.0.0.Final", "1.0-sp1", "1.0.1", "123456789012345678901234567890"},
+			{"1-0.alpha", "1-0.beta", "1", "1-0.1", "1-1"}, // MNG-6964
+		} {
+			for i := 0; i+1 < len(ordered); i++ {
+				a, b := mustParseMaven(ordered[i]), mustParseMaven(ordered[i+1])
+				Convey(ordered[i]+" < "+ordered[i+1], func() {
+					So(a.Compare(b), ShouldEqual, -1)
+					So(b.Compare(a), ShouldEqual, 1)
+				})
+			}
+		}
+	})
+
+	Convey("MavenVersions are equal…", t, FailureContinues, func() {
+		for _, equal := range [][]string{
+			{"1", "1.0", "1.0.0", "1-0", "1.0-0", "1ga", "1release", "1final", "1-ga", "1.final"},
+			{"1a", "1-a", "1.0-a", "1.0.0-a", "1.0a", "1.0.0a", "1A"},
+			{"1x", "1-x", "1.0-x", "1.0.0-x", "1.0x", "1.0.0x", "1X"},
+			{"1a1", "1-alpha-1", "1-A1", "1ALPHA1"},
+			{"1b2", "1-beta-2"},
+			{"1m3", "1-milestone-3"},
+			{"1rc", "1cr", "1-CR"},
+		} {
+			for _, str := range equal[1:] {
+				a, b := mustParseMaven(equal[0]), mustParseMaven(str)
+				Convey(equal[0]+" and "+str, func() {
+					So(a.Compare(b), ShouldEqual, 0)
+					So(a.Canonical(), ShouldEqual, b.Canonical())
+				})
+			}
+		}
+	})
+
+	Convey("Canonical strips what does not matter", t, func() {
+		So(mustParseMaven("1.0-SNAPSHOT").Canonical(), ShouldEqual, "1-snapshot")
+		So(mustParseMaven("1.0.0-a2").Canonical(), ShouldEqual, "1-alpha-2")
+		So(mustParseMaven("1.0.0-a2").String(), ShouldEqual, "1.0.0-a2")
+		So(mustParseMaven("2.0.0.a").Canonical(), ShouldEqual, "2.0.0.a")
+	})
+
+	Convey("NewMavenVersion rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":      0,
+			"1.0 ":  3,
+			"1,0":   1,
+			"[1.0]": 0,
+		} {
+			Convey(str, func() {
+				_, err := ParseMaven(str)
+				So(errors.Is(err, errInvalidMavenVersion), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+
+	Convey("MavenVersionPtrs sorts, nil last", t, func() {
+		ordered := []string{"1.0-alpha1", "1.0-SNAPSHOT", "1.0.0.Final", "1.0-sp1", "1.0.1"}
+		ptrs := MavenVersionPtrs{nil}
+		for i := len(ordered) - 1; i >= 0; i-- {
+			v := mustParseMaven(ordered[i])
+			ptrs = append(ptrs, &v)
+		}
+		ptrs.Sort()
+		for i := range ordered {
+			So(ptrs[i].String(), ShouldEqual, ordered[i])
+		}
+		So(ptrs[len(ordered)], ShouldBeNil)
+	})
+
+	Convey("MavenVersion is written as it's read, not canonical…", t, FailureContinues, func() {
+		Convey("keeping the case of qualifiers", func() {
+			v := mustParseMaven("1.0.0.Final")
+			out, err := json.Marshal(v)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `"1.0.0.Final"`)
+			var back MavenVersion
+			So(json.Unmarshal([]byte(`"1.0.0.FINAL"`), &back), ShouldBeNil)
+			So(back.Compare(v), ShouldEqual, 0)
+			So(back.String(), ShouldEqual, "1.0.0.FINAL")
+		})
+
+		Convey("escaping quotes and backslashes, which Maven allows in qualifiers", func() {
+			v := mustParseMaven(`1.0-"rc"\1`)
+			out, err := json.Marshal(v)
+			So(err, ShouldBeNil)
+			So(json.Valid(out), ShouldBeTrue)
+			So(string(out), ShouldEqual, `"1.0-\"rc\"\\1"`)
+			var back MavenVersion
+			So(json.Unmarshal(out, &back), ShouldBeNil)
+			So(back, ShouldResemble, v)
+		})
+	})
+
+	Convey("MavenVersion is scanned from databases", t, func() {
+		v := mustParseMaven("1.0-SNAPSHOT")
+		val, _ := v.Value()
+		var back MavenVersion
+		So(back.Scan(val), ShouldBeNil)
+		So(back, ShouldResemble, v)
+		So(errors.Is(back.Scan("[1.0,2.0)"), errInvalidMavenVersion), ShouldBeTrue)
+		So(back.Scan(3.14), ShouldEqual, errInvalidMavenType)
+	})
+}
+
+func TestMavenRange(t *testing.T) {
+	Convey("MavenRange contains…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"1.0":                         {"0.1": true, "1.0": true, "9": true},
+			"[1.0]":                       {"1.0": true, "1": true, "1.0.1": false, "1.0-SNAPSHOT": false},
+			"[1.0,2.0)":                   {"1.0": true, "1.5-SNAPSHOT": true, "2.0-SNAPSHOT": true, "2.0": false, "0.9": false},
+			"(1.0,2.0]":                   {"1.0": false, "1.0-sp1": true, "2.0": true, "2.0.Final": true, "2.0.1": false},
+			"(,1.0],[1.2,)":               {"0.1": true, "1.0": true, "1.1": false, "1.2-rc1": false, "1.2": true, "9": true},
+			" [ 1.0 , 2.0 ) , ( 3.0 , ) ": {"1.0": true, "2.5": false, "3.0": false, "3.1": true},
+			"[1,2],[2,3]":                 {"2": true, "3": true},
+		} {
+			r, err := ParseMavenRange(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(r.Contains(mustParseMaven(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("MavenRange is written as it's read", t, func() {
+		for str, expected := range map[string]string{
+			"1.0":                      "1.0",
+			"[1.0]":                    "[1.0]",
+			"(,1.0],[1.2,)":            "(,1.0],[1.2,)",
+			" [ 1.0 , 2.0 ) , (3.0,) ": "[1.0,2.0),(3.0,)",
+		} {
+			r, err := ParseMavenRange(str)
+			So(err, ShouldBeNil)
+			So(r.String(), ShouldEqual, expected)
+		}
+		r, _ := ParseMavenRange("1.0")
+		So(r.Recommended().String(), ShouldEqual, "1.0")
+		r, _ = ParseMavenRange("[1.0]")
+		So(r.Recommended(), ShouldBeNil)
+	})
+
+	Convey("NewMavenRange rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":                 0,
+			"[1.0,2.0":         8,
+			"(1.0)":            0,
+			"[1.0,1.0]":        5,
+			"[2.0,1.0]":        5,
+			"[1.0,2.0),[1.5,)": 10,
+			"[1.0,),[2.0,)":    7,
+			"[1.0,2.0) x":      10,
+			"[1.0,2.0),":       10,
+			"[1.0,2 0)":        6,
+			"1.0,2.0":          3,
+		} {
+			Convey(str, func() {
+				_, err := ParseMavenRange(str)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Input, ShouldEqual, str)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
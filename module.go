@@ -0,0 +1,410 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"database/sql/driver"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Errors that are thrown when reading or inspecting a ModuleVersion.
+const (
+	errInvalidModuleVersion InvalidStringValue = "Given string does not resemble a Go module version"
+	errInvalidModuleType    InvalidStringValue = "Cannot read this type into a ModuleVersion"
+	errNotPseudoVersion     InvalidStringValue = "Given version is no valid pseudo-version"
+	errInvalidModulePath    InvalidStringValue = "Module path does not match the major version"
+)
+
+// pseudoTimeFormat is the layout of the timestamp in pseudo-versions.
+const pseudoTimeFormat = "20060102150405"
+
+// ModuleVersion is a version of a Go module, such as "v1.2.3", "v2.3.4+incompatible",
+// or the pseudo-version "v0.0.0-20210101120000-abcdef123456",
+// with the semantics of golang.org/x/mod/semver.
+//
+// Unlike Version it must start with 'v', can have numbers of arbitrary length,
+// and can be shortened to "v1" or "v1.2", which is the same as "v1.0.0" or "v1.2.0".
+type ModuleVersion struct {
+	str        string
+	major      string
+	minor      string
+	patch      string
+	preRelease string // Without the leading '-'.
+	build      string // Without the leading '+'.
+}
+
+// NewModuleVersion reads a version of a Go module, which golang.org/x/mod/semver calls valid.
+// Like the go command this rejects "+incompatible" below v2, such as in "v1.2.3+incompatible".
+func NewModuleVersion(str []byte) (ModuleVersion, error) {
+	v := ModuleVersion{str: string(str)}
+	if len(str) == 0 || str[0] != 'v' {
+		return ModuleVersion{}, newParseError(errInvalidModuleVersion, str, 0, "'v'")
+	}
+	idx := 1
+	for i, column := range [...]*string{&v.major, &v.minor, &v.patch} {
+		if i > 0 && idx >= len(str) { // "v1" and "v1.2" are short for "v1.0.0" and "v1.2.0".
+			if v.minor == "" {
+				v.minor = "0"
+			}
+			v.patch = "0"
+			return v, nil
+		}
+		if i > 0 {
+			if str[idx] != '.' {
+				return ModuleVersion{}, newParseError(errInvalidModuleVersion, str, idx, "'.'")
+			}
+			idx++
+		}
+		n := countDigits(str[idx:])
+		switch {
+		case n == 0:
+			return ModuleVersion{}, newParseError(errInvalidModuleVersion, str, idx, "a digit")
+		case n > 1 && str[idx] == '0':
+			return ModuleVersion{}, newParseError(errInvalidModuleVersion, str, idx, "a number without leading zeroes")
+		}
+		*column = v.str[idx : idx+n]
+		idx += n
+	}
+
+	if idx < len(str) && str[idx] == '-' {
+		n, err := countModuleIdentifiers(str, idx+1, true)
+		if err != nil {
+			return ModuleVersion{}, err
+		}
+		v.preRelease = v.str[idx+1 : idx+1+n]
+		idx += 1 + n
+	}
+	if idx < len(str) && str[idx] == '+' {
+		n, err := countModuleIdentifiers(str, idx+1, false)
+		if err != nil {
+			return ModuleVersion{}, err
+		}
+		v.build = v.str[idx+1 : idx+1+n]
+		if v.IsIncompatible() && (v.major == "0" || v.major == "1") {
+			return ModuleVersion{}, newParseError(errInvalidModuleVersion, str, idx, "a major version of 2 or above for +incompatible")
+		}
+		idx += 1 + n
+	}
+	if idx < len(str) {
+		return ModuleVersion{}, newParseError(errInvalidModuleVersion, str, idx, "'-', '+', or the end")
+	}
+	return v, nil
+}
+
+// ParseModuleVersion is NewModuleVersion for strings.
+func ParseModuleVersion(str string) (ModuleVersion, error) {
+	return NewModuleVersion([]byte(str))
+}
+
+// countModuleIdentifiers returns the length of the dot-separated identifiers at idx,
+// which consist of [0-9A-Za-z-]. Numeric identifiers of pre-releases must not have leading zeroes.
+func countModuleIdentifiers(str []byte, idx int, isPreRelease bool) (int, error) {
+	start := idx
+	for {
+		n := 0
+		for idx+n < len(str) && (isNumeric(str[idx+n]) || isSmallLetter(str[idx+n]|0x20) || str[idx+n] == '-') {
+			n++
+		}
+		switch {
+		case n == 0:
+			return 0, newParseError(errInvalidModuleVersion, str, idx, "a letter, digit, or '-'")
+		case isPreRelease && n > 1 && str[idx] == '0' && isNumericIdentifier(str[idx:idx+n]):
+			return 0, newParseError(errInvalidModuleVersion, str, idx, "a number without leading zeroes")
+		}
+		idx += n
+		if idx >= len(str) || str[idx] != '.' {
+			return idx - start, nil
+		}
+		idx++
+	}
+}
+
+// String returns the version as it has been read.
+func (v ModuleVersion) String() string {
+	return v.str
+}
+
+// Canonical returns the version in the notation "vMAJOR.MINOR.PATCH[-PRERELEASE]"
+// without any build metadata, as golang.org/x/mod/semver.Canonical does: "v1.2.0" for "v1.2",
+// or "v2.3.4" for "v2.3.4+incompatible".
+func (v ModuleVersion) Canonical() string {
+	if v.major == "" {
+		return ""
+	}
+	str := "v" + v.major + "." + v.minor + "." + v.patch
+	if v.preRelease != "" {
+		str += "-" + v.preRelease
+	}
+	return str
+}
+
+// Major returns the major version prefix, such as "v2" for "v2.3.4".
+func (v ModuleVersion) Major() string {
+	if v.major == "" {
+		return ""
+	}
+	return "v" + v.major
+}
+
+// PreRelease returns the pre-release without its leading hyphen, such as "rc.1" for "v1.0.0-rc.1",
+// or the empty string if there's none.
+func (v ModuleVersion) PreRelease() string {
+	return v.preRelease
+}
+
+// Build returns the build metadata without its leading plus sign, such as "incompatible" for "v2.3.4+incompatible",
+// or the empty string if there's none.
+func (v ModuleVersion) Build() string {
+	return v.build
+}
+
+// IsIncompatible is true for versions with the build suffix "+incompatible",
+// which denotes a major version two or above of a module without go.mod file.
+func (v ModuleVersion) IsIncompatible() bool {
+	return v.build == "incompatible"
+}
+
+// Compare returns the signum of the difference between v and o, as golang.org/x/mod/semver orders them:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// Build metadata is ignored, and pre-releases are ordered by §11 SemVer.
+// The zero value is lower than any other ModuleVersion.
+func (v ModuleVersion) Compare(o ModuleVersion) int {
+	if v.major == "" || o.major == "" {
+		return signum(len(v.major) - len(o.major))
+	}
+	if c := compareDecimals(v.major, o.major); c != 0 {
+		return c
+	}
+	if c := compareDecimals(v.minor, o.minor); c != 0 {
+		return c
+	}
+	if c := compareDecimals(v.patch, o.patch); c != 0 {
+		return c
+	}
+	switch {
+	case v.preRelease == o.preRelease:
+		return 0
+	case v.preRelease == "":
+		return 1
+	case o.preRelease == "":
+		return -1
+	}
+	return compareIdentifiers([]byte(v.preRelease), []byte(o.preRelease))
+}
+
+// Less is a convenience function for sorting.
+func (v *ModuleVersion) Less(o *ModuleVersion) bool {
+	return v.Compare(*o) < 0
+}
+
+// pseudo returns the parts of a pseudo-version, with the base version in its string representation
+// up to and excluding the timestamp, such as "v1.2.4-0" for "v1.2.4-0.20210101120000-abcdef123456".
+func (v ModuleVersion) pseudo() (base, timestamp, revision string, ok bool) {
+	hyphen := strings.LastIndexByte(v.preRelease, '-')
+	if hyphen < len(pseudoTimeFormat) || v.preRelease[hyphen+1:] == "" {
+		return "", "", "", false
+	}
+	revision, timestamp = v.preRelease[hyphen+1:], v.preRelease[hyphen-len(pseudoTimeFormat):hyphen]
+	if countDigits([]byte(timestamp)) != len(timestamp) || strings.IndexByte(revision, '.') >= 0 {
+		return "", "", "", false
+	}
+	switch prefix := v.preRelease[:hyphen-len(timestamp)]; {
+	case prefix == "" && v.minor == "0" && v.patch == "0":
+		base = "v" + v.major + ".0.0"
+	case prefix == "0." || strings.HasSuffix(prefix, ".0."):
+		base = "v" + v.major + "." + v.minor + "." + v.patch + "-" + prefix[:len(prefix)-1]
+	default:
+		return "", "", "", false
+	}
+	return base, timestamp, revision, true
+}
+
+// IsPseudo is true for pseudo-versions, which the go command generates for untagged revisions,
+// such as "v0.0.0-20210101120000-abcdef123456", "v1.2.4-0.20210101120000-abcdef123456",
+// or "v1.2.3-pre.0.20210101120000-abcdef123456".
+func (v ModuleVersion) IsPseudo() bool {
+	_, _, _, ok := v.pseudo()
+	return ok
+}
+
+// PseudoBase returns the version the pseudo-version is derived from, including any "+incompatible":
+// "v1.2.3" for "v1.2.4-0.20210101120000-abcdef123456",
+// "v1.2.3-pre" for "v1.2.3-pre.0.20210101120000-abcdef123456",
+// and the empty string if there's none, like in "v0.0.0-20210101120000-abcdef123456".
+func (v ModuleVersion) PseudoBase() (string, error) {
+	base, _, _, ok := v.pseudo()
+	if !ok {
+		return "", errNotPseudoVersion
+	}
+	build := ""
+	if v.build != "" {
+		build = "+" + v.build
+	}
+	switch {
+	case strings.IndexByte(base, '-') < 0:
+		if build != "" { // No base, yet incompatible to it, doesn't make sense.
+			return "", errNotPseudoVersion
+		}
+		return "", nil
+	case strings.HasSuffix(base, "-0"):
+		patch := decrementDecimal(v.patch)
+		if patch == "" {
+			return "", errNotPseudoVersion
+		}
+		return "v" + v.major + "." + v.minor + "." + patch + build, nil
+	}
+	return strings.TrimSuffix(base, ".0") + build, nil
+}
+
+// PseudoTime returns the timestamp of the revision in a pseudo-version, in UTC.
+func (v ModuleVersion) PseudoTime() (time.Time, error) {
+	_, timestamp, _, ok := v.pseudo()
+	if !ok {
+		return time.Time{}, errNotPseudoVersion
+	}
+	return time.Parse(pseudoTimeFormat, timestamp)
+}
+
+// PseudoRevision returns the revision identifier in a pseudo-version, such as "abcdef123456",
+// which for Git is a prefix of the commit hash.
+func (v ModuleVersion) PseudoRevision() (string, error) {
+	_, _, revision, ok := v.pseudo()
+	if !ok {
+		return "", errNotPseudoVersion
+	}
+	return revision, nil
+}
+
+// decrementDecimal returns the number one less than the given one,
+// or the empty string if it's zero.
+func decrementDecimal(str string) string {
+	digits := []byte(str)
+	i := len(digits) - 1
+	for ; i >= 0 && digits[i] == '0'; i-- {
+		digits[i] = '9'
+	}
+	if i < 0 {
+		return ""
+	}
+	digits[i]--
+	if digits[0] == '0' && len(digits) > 1 {
+		digits = digits[1:]
+	}
+	return string(digits)
+}
+
+// CheckPath returns nil if the path of a module fits its version:
+// A path such as "example.com/mod/v2" requires major version 2,
+// and "gopkg.in/yaml.v2" version 2 as well. Without such a suffix
+// the major version must be 0 or 1, unless the version is "+incompatible".
+//
+// Else it returns a ParseError about the path, such as
+// "… unexpected end at offset 15 of "example.com/mod", expected /v2".
+func (v ModuleVersion) CheckPath(path string) error {
+	start, suffix := len(path), ""
+	if strings.HasPrefix(path, "gopkg.in/") {
+		if idx := strings.LastIndex(path, ".v"); idx > strings.LastIndexByte(path, '/') {
+			start, suffix = idx, strings.TrimSuffix(path[idx:], "-unstable")
+		}
+		if v.major == "0" && suffix == ".v1" && v.IsPseudo() { // A bug in old versions of the go command.
+			return nil
+		}
+		if suffix != "" && suffix == ".v"+v.major {
+			return nil
+		}
+		return newParseError(errInvalidModulePath, []byte(path), start, ".v"+v.major)
+	}
+
+	if idx := strings.LastIndexByte(path, '/'); idx >= 0 && strings.HasPrefix(path[idx:], "/v") {
+		if n := countDigits([]byte(path[idx+2:])); n > 0 && idx+2+n == len(path) {
+			start, suffix = idx, path[idx:]
+		}
+	}
+	isValidSuffix := suffix != "/v0" && suffix != "/v1" && !strings.HasPrefix(suffix, "/v0")
+	switch {
+	case suffix == "" && (v.major == "0" || v.major == "1" || v.IsIncompatible()):
+		return nil
+	case suffix == "":
+		return newParseError(errInvalidModulePath, []byte(path), start, "/v"+v.major)
+	case v.IsIncompatible():
+		return newParseError(errInvalidModulePath, []byte(path), start, "no major version suffix for +incompatible")
+	case suffix == "/v"+v.major && isValidSuffix:
+		return nil
+	case v.major == "0" || v.major == "1":
+		return newParseError(errInvalidModulePath, []byte(path), start, "no major version suffix")
+	}
+	return newParseError(errInvalidModulePath, []byte(path), start, "/v"+v.major)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v ModuleVersion) MarshalJSON() ([]byte, error) {
+	return quoteJSON(v.str), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v ModuleVersion) MarshalText() ([]byte, error) {
+	return []byte(v.str), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *ModuleVersion) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, v.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *ModuleVersion) UnmarshalText(b []byte) error {
+	mv, err := NewModuleVersion(b)
+	if err != nil {
+		return err
+	}
+	*v = mv
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *ModuleVersion) Scan(src interface{}) error {
+	return scanText(src, v.UnmarshalText, errInvalidModuleType)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (v ModuleVersion) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// ModuleVersionPtrs represents an array with elements derived from ModuleVersion.
+// Use it to sort them, in the order the go command does.
+type ModuleVersionPtrs []*ModuleVersion
+
+// Len implements the sort.Interface.
+func (p ModuleVersionPtrs) Len() int {
+	return len(p)
+}
+
+// Swap implements the sort.Interface.
+func (p ModuleVersionPtrs) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements the sort.Interface.
+func (p ModuleVersionPtrs) Less(i, j int) bool {
+	if p[i] == nil {
+		return false
+	} else if p[j] == nil {
+		return true
+	}
+	return p[i].Less(p[j])
+}
+
+// Sort reorders the pointers so that the ModuleVersions appear in ascending order.
+// Any nil pointers go last.
+func (p ModuleVersionPtrs) Sort() {
+	sort.Stable(p)
+}
@@ -0,0 +1,276 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParseModuleVersion(str string) ModuleVersion {
+	v, err := ParseModuleVersion(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestNewModuleVersion(t *testing.T) {
+	// Mostly from the tests of golang.org/x/mod/semver.
+	Convey("NewModuleVersion reads…", t, FailureContinues, func() {
+		for str, parts := range map[string][2]string{
+			"v1":                                 {"v1.0.0", "v1"},
+			"v1.2":                               {"v1.2.0", "v1"},
+			"v1.2.3":                             {"v1.2.3", "v1"},
+			"v0.0.0":                             {"v0.0.0", "v0"},
+			"v1.2.3-alpha.1":                     {"v1.2.3-alpha.1", "v1"},
+			"v1.2.3-0a.0-x":                      {"v1.2.3-0a.0-x", "v1"},
+			"v1.2.3+meta-data":                   {"v1.2.3", "v1"},
+			"v2.3.4+incompatible":                {"v2.3.4", "v2"},
+			"v0.0.0-20210101120000-abcdef123456": {"v0.0.0-20210101120000-abcdef123456", "v0"},
+			"v12345678901234567890.0.1":          {"v12345678901234567890.0.1", "v12345678901234567890"},
+			"v3.0.0-20210101120000-abcdef123456+incompatible":       {"v3.0.0-20210101120000-abcdef123456", "v3"},
+			"v1.2.4-0.20210101120000-abcdef123456":                  {"v1.2.4-0.20210101120000-abcdef123456", "v1"},
+			"v2.2.3-pre.0.20210101120000-abcdef123456+incompatible": {"v2.2.3-pre.0.20210101120000-abcdef123456", "v2"},
+		} {
+			Convey(str, func() {
+				v, err := ParseModuleVersion(str)
+				So(err, ShouldBeNil)
+				So(v.String(), ShouldEqual, str)
+				So(v.Canonical(), ShouldEqual, parts[0])
+				So(v.Major(), ShouldEqual, parts[1])
+			})
+		}
+	})
+
+	Convey("NewModuleVersion rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":              0,
+			"1.2.3":         0,
+			"v":             1,
+			"v01.2.3":       1,
+			"v1.2.03":       5,
+			"v1.2-pre":      4,
+			"v1+meta":       2,
+			"v1.2.3.4":      6,
+			"v1.2.3-01":     7,
+			"v1.2.3-a..b":   9,
+			"v1.2.3-":       7,
+			"v1.2.3+":       7,
+			"v1.2.3+meta+x": 11,
+			"v1.2.3-é":      7,
+			"V1.2.3":        0,
+
+			"v1.2.3-rc.1+incompatible": 11,
+			"v0.1.0+incompatible":      6,
+		} {
+			Convey(str, func() {
+				_, err := ParseModuleVersion(str)
+				So(errors.Is(err, errInvalidModuleVersion), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+
+	Convey("+incompatible is recognized", t, func() {
+		So(mustParseModuleVersion("v2.3.4+incompatible").IsIncompatible(), ShouldBeTrue)
+		So(mustParseModuleVersion("v2.3.4+incompatible").Build(), ShouldEqual, "incompatible")
+		So(mustParseModuleVersion("v2.3.4+compatible").IsIncompatible(), ShouldBeFalse)
+		So(mustParseModuleVersion("v2.3.4-rc.1").PreRelease(), ShouldEqual, "rc.1")
+	})
+}
+
+func TestModuleVersionPseudo(t *testing.T) {
+	timestamp := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	Convey("Pseudo-versions are…", t, FailureContinues, func() {
+		for str, base := range map[string]string{
+			"v0.0.0-20210101120000-abcdef123456":                    "",
+			"v2.0.0-20210101120000-abcdef123456":                    "",
+			"v1.2.4-0.20210101120000-abcdef123456":                  "v1.2.3",
+			"v1.2.10-0.20210101120000-abcdef123456":                 "v1.2.9",
+			"v2.2.4-0.20210101120000-abcdef123456+incompatible":     "v2.2.3+incompatible",
+			"v1.2.3-pre.0.20210101120000-abcdef123456":              "v1.2.3-pre",
+			"v1.2.3-rc.1.0.20210101120000-abcdef123456":             "v1.2.3-rc.1",
+			"v2.2.3-pre.0.20210101120000-abcdef123456+incompatible": "v2.2.3-pre+incompatible",
+		} {
+			Convey(str, func() {
+				v := mustParseModuleVersion(str)
+				So(v.IsPseudo(), ShouldBeTrue)
+				b, err := v.PseudoBase()
+				So(err, ShouldBeNil)
+				So(b, ShouldEqual, base)
+				ts, err := v.PseudoTime()
+				So(err, ShouldBeNil)
+				So(ts, ShouldResemble, timestamp)
+				rev, err := v.PseudoRevision()
+				So(err, ShouldBeNil)
+				So(rev, ShouldEqual, "abcdef123456")
+			})
+		}
+	})
+
+	Convey("No pseudo-versions are…", t, FailureContinues, func() {
+		for _, str := range []string{
+			"v1.2.3",
+			"v1.2.3-pre",
+			"v1.2.3-20210101120000-abcdef123456",
+			"v1.2.3-pre.20210101120000-abcdef123456",
+			"v0.0.0-2021010112000-abcdef123456",
+			"v0.0.0-20210101120000-",
+		} {
+			Convey(str, func() {
+				v := mustParseModuleVersion(str)
+				So(v.IsPseudo(), ShouldBeFalse)
+				_, err := v.PseudoBase()
+				So(err, ShouldEqual, errNotPseudoVersion)
+				_, err = v.PseudoTime()
+				So(err, ShouldEqual, errNotPseudoVersion)
+				_, err = v.PseudoRevision()
+				So(err, ShouldEqual, errNotPseudoVersion)
+			})
+		}
+	})
+
+	Convey("Nonsensical pseudo-versions have no base", t, func() {
+		for _, str := range []string{
+			"v1.2.0-0.20210101120000-abcdef123456",
+			"v2.0.0-20210101120000-abcdef123456+incompatible",
+		} {
+			_, err := mustParseModuleVersion(str).PseudoBase()
+			So(err, ShouldEqual, errNotPseudoVersion)
+		}
+	})
+}
+
+func TestModuleVersionOrder(t *testing.T) {
+	ordered := []string{
+		"v0.0.0-20210101120000-abcdef123456", "v0.0.0", "v0.1", "v1.0.0-alpha", "v1.0.0-alpha.1",
+		"v1.0.0-alpha.beta", "v1.0.0-beta", "v1.0.0-beta.2", "v1.0.0-beta.11", "v1.0.0-rc.1", "v1",
+		"v1.2.4-0.20210101120000-abcdef123456", "v1.2.4", "v2.3.4+incompatible", "v12345678901234567890",
+	}
+
+	Convey("ModuleVersions are ordered as golang.org/x/mod/semver does", t, func() {
+		for i := 0; i+1 < len(ordered); i++ {
+			a, b := mustParseModuleVersion(ordered[i]), mustParseModuleVersion(ordered[i+1])
+			So(a.Compare(b), ShouldEqual, -1)
+			So(b.Compare(a), ShouldEqual, 1)
+		}
+		So(mustParseModuleVersion("v1.2").Compare(mustParseModuleVersion("v1.2.0+meta")), ShouldEqual, 0)
+		So(ModuleVersion{}.Compare(mustParseModuleVersion("v0.0.0")), ShouldEqual, -1)
+
+		Convey("also by ModuleVersionPtrs, nil last", func() {
+			ptrs := ModuleVersionPtrs{nil}
+			for i := len(ordered) - 1; i >= 0; i-- {
+				v := mustParseModuleVersion(ordered[i])
+				ptrs = append(ptrs, &v)
+			}
+			ptrs.Sort()
+			for i := range ordered {
+				So(ptrs[i].String(), ShouldEqual, ordered[i])
+			}
+			So(ptrs[len(ordered)], ShouldBeNil)
+		})
+	})
+
+	Convey("ModuleVersion is read from the JSON of go list -m -json…", t, FailureContinues, func() {
+		var modules []struct {
+			Path    string
+			Version ModuleVersion
+			Update  *struct{ Version ModuleVersion }
+		}
+		So(json.Unmarshal([]byte(`[
+			{"Path": "golang.org/x/mod", "Version": "v0.14.0", "Update": {"Version": "v0.15.0"}},
+			{"Path": "github.com/x/y", "Version": "v0.0.0-20210101120000-abcdef123456"},
+			{"Path": "github.com/x/z", "Version": "v2.3.4+incompatible", "Update": null}
+		]`), &modules), ShouldBeNil)
+
+		Convey("with updates", func() {
+			So(modules[0].Version.Less(&modules[0].Update.Version), ShouldBeTrue)
+			So(modules[2].Update, ShouldBeNil)
+		})
+		Convey("with pseudo-versions", func() {
+			So(modules[1].Version.IsPseudo(), ShouldBeTrue)
+			out, err := json.Marshal(modules[1].Version)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `"v0.0.0-20210101120000-abcdef123456"`)
+		})
+		Convey("with +incompatible", func() {
+			So(modules[2].Version.IsIncompatible(), ShouldBeTrue)
+			So(modules[2].Version.CheckPath(modules[2].Path), ShouldBeNil)
+		})
+	})
+
+	Convey("ModuleVersion is written as it's read, not canonical", t, func() {
+		v := mustParseModuleVersion("v1.2")
+		text, err := v.MarshalText()
+		So(err, ShouldBeNil)
+		So(string(text), ShouldEqual, "v1.2")
+		So(v.Canonical(), ShouldEqual, "v1.2.0")
+	})
+
+	Convey("ModuleVersion is scanned from databases", t, func() {
+		v := mustParseModuleVersion("v2.3.4+incompatible")
+		val, _ := v.Value()
+		var back ModuleVersion
+		So(back.Scan(val), ShouldBeNil)
+		So(back, ShouldResemble, v)
+		So(errors.Is(back.Scan([]byte("1.2.3")), errInvalidModuleVersion), ShouldBeTrue)
+		So(back.Scan(3.14), ShouldEqual, errInvalidModuleType)
+	})
+}
+
+func TestModuleVersionCheckPath(t *testing.T) {
+	Convey("CheckPath accepts…", t, FailureContinues, func() {
+		for path, versions := range map[string][]string{
+			"example.com/mod":            {"v0.1.0", "v1.2.3", "v2.3.4+incompatible", "v0.0.0-20210101120000-abcdef123456"},
+			"example.com/mod/v2":         {"v2.0.0", "v2.3.4-rc.1", "v2.0.0-20210101120000-abcdef123456"},
+			"example.com/v2/mod":         {"v1.0.0"},
+			"example.com/mod/v10":        {"v10.1.0"},
+			"gopkg.in/yaml.v2":           {"v2.4.0"},
+			"gopkg.in/yaml.v0":           {"v0.1.0"},
+			"gopkg.in/yaml.v1":           {"v1.0.0", "v0.0.0-20210101120000-abcdef123456"},
+			"gopkg.in/check.v3-unstable": {"v3.0.0"},
+		} {
+			for _, version := range versions {
+				Convey(path+" with "+version, func() {
+					So(mustParseModuleVersion(version).CheckPath(path), ShouldBeNil)
+				})
+			}
+		}
+	})
+
+	Convey("CheckPath rejects, and tells where…", t, FailureContinues, func() {
+		for _, tc := range []struct {
+			path, version string
+			offset        int
+			expected      string
+		}{
+			{"example.com/mod", "v2.0.0", 15, "/v2"},
+			{"example.com/mod/v2", "v3.0.0", 15, "/v3"},
+			{"example.com/mod/v2", "v1.0.0", 15, "no major version suffix"},
+			{"example.com/mod/v2", "v2.0.0+incompatible", 15, "no major version suffix for +incompatible"},
+			{"example.com/mod/v1", "v1.0.0", 15, "no major version suffix"},
+			{"example.com/mod/v02", "v2.0.0", 15, "/v2"},
+			{"gopkg.in/yaml.v2", "v3.0.0", 13, ".v3"},
+			{"gopkg.in/yaml", "v1.0.0", 13, ".v1"},
+		} {
+			Convey(tc.path+" with "+tc.version, func() {
+				err := mustParseModuleVersion(tc.version).CheckPath(tc.path)
+				So(errors.Is(err, errInvalidModulePath), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, tc.offset)
+				So(e.Expected, ShouldEqual, tc.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,552 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Errors that are thrown when reading a NuGetVersion or NuGetRange.
+const (
+	errInvalidNuGetVersion InvalidStringValue = "Given string does not resemble a NuGet version"
+	errInvalidNuGetType    InvalidStringValue = "Cannot read this type into a NuGetVersion"
+	errInvalidNuGetRange   InvalidStringValue = "Given string does not resemble a NuGet version range"
+)
+
+// NuGetVersion is a version of a NuGet package, such as "1.2.3.4" or "1.0.0-Beta.2+sha.5114f85",
+// ordered as NuGet does.
+//
+// It has up to four numbers, the last of which is the legacy revision,
+// and pre-release labels which, unlike in SemVer, are compared regardless of case.
+type NuGetVersion struct {
+	numbers  [4]int32
+	release  string // Dot-separated pre-release labels.
+	metadata string
+}
+
+// NewNuGetVersion reads a version of a NuGet package: one to four numbers separated by dots,
+// optionally followed by a pre-release of dot-separated labels after a hyphen,
+// and build metadata after a plus.
+// Surrounding whitespace is ignored.
+func NewNuGetVersion(str []byte) (NuGetVersion, error) {
+	var v NuGetVersion
+	idx := skipSpace(str, 0)
+	end := idx + len(bytes.TrimRightFunc(str[idx:], unicode.IsSpace))
+	for column := 0; ; column++ {
+		n, num := atoui(str[idx:end])
+		switch {
+		case n == 0:
+			return NuGetVersion{}, newParseError(errInvalidNuGetVersion, str, idx, "a digit")
+		case n >= 10:
+			return NuGetVersion{}, newParseError(errInvalidNuGetVersion, str, idx+9, "at most 9 digits")
+		}
+		v.numbers[column] = int32(num)
+		idx += n
+		if idx >= end || str[idx] != '.' {
+			break
+		}
+		if column == len(v.numbers)-1 {
+			return NuGetVersion{}, newParseError(errInvalidNuGetVersion, str, idx, "at most four numbers")
+		}
+		idx++
+	}
+
+	if idx < end && str[idx] == '-' {
+		n, err := nugetLabels(str, idx+1, end)
+		if err != nil {
+			return NuGetVersion{}, err
+		}
+		v.release = string(str[idx+1 : idx+1+n])
+		idx += 1 + n
+	}
+	if idx < end && str[idx] == '+' {
+		n, err := nugetLabels(str, idx+1, end)
+		if err != nil {
+			return NuGetVersion{}, err
+		}
+		v.metadata = string(str[idx+1 : idx+1+n])
+		idx += 1 + n
+	}
+	if idx < end {
+		return NuGetVersion{}, newParseError(errInvalidNuGetVersion, str, idx, "'.', '-', '+', or the end")
+	}
+	return v, nil
+}
+
+// ParseNuGet is NewNuGetVersion for strings.
+func ParseNuGet(str string) (NuGetVersion, error) {
+	return NewNuGetVersion([]byte(str))
+}
+
+// nugetLabels returns the length of the non-empty labels of [0-9A-Za-z-], separated by dots,
+// that start at idx.
+func nugetLabels(str []byte, idx, end int) (int, error) {
+	start := idx
+	for {
+		n := 0
+		for idx+n < end && (isNumeric(str[idx+n]) || isSmallLetter(str[idx+n]|0x20) || str[idx+n] == '-') {
+			n++
+		}
+		if n == 0 {
+			return 0, newParseError(errInvalidNuGetVersion, str, idx, "a letter, digit, or '-'")
+		}
+		idx += n
+		if idx >= end || str[idx] != '.' {
+			return idx - start, nil
+		}
+		idx++
+	}
+}
+
+// String returns the version as NuGet normalizes it,
+// with at least three numbers and the revision only if it is not zero.
+func (v NuGetVersion) String() string {
+	return string(v.serialize())
+}
+
+// IsPreRelease is true if the version has pre-release labels, such as "1.0.0-beta".
+func (v NuGetVersion) IsPreRelease() bool {
+	return v.release != ""
+}
+
+// Compare returns the signum of the difference between v and o, as NuGet orders them:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// The numbers are compared first, and then the pre-release labels as SemVer does,
+// but regardless of case. Any build metadata is ignored.
+func (v NuGetVersion) Compare(o NuGetVersion) int {
+	for i := range v.numbers {
+		if v.numbers[i] != o.numbers[i] {
+			return signum(int(v.numbers[i]) - int(o.numbers[i]))
+		}
+	}
+	switch {
+	case v.release == o.release:
+		return 0
+	case v.release == "":
+		return 1
+	case o.release == "":
+		return -1
+	}
+	return compareIdentifiers(bytes.ToUpper([]byte(v.release)), bytes.ToUpper([]byte(o.release)))
+}
+
+// Less is a convenience function for sorting.
+func (v *NuGetVersion) Less(o *NuGetVersion) bool {
+	return v.Compare(*o) < 0
+}
+
+// sharesNumbersWith is true if the first given numbers of both versions are equal.
+func (v NuGetVersion) sharesNumbersWith(o NuGetVersion, columns int) bool {
+	for i := 0; i < columns; i++ {
+		if v.numbers[i] != o.numbers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// serialize returns the version as String does.
+func (v NuGetVersion) serialize() []byte {
+	target := make([]byte, 0, 16+len(v.release)+len(v.metadata))
+	columns := 3
+	if v.numbers[3] != 0 {
+		columns = 4
+	}
+	for i := 0; i < columns; i++ {
+		if i > 0 {
+			target = append(target, '.')
+		}
+		target = strconv.AppendInt(target, int64(v.numbers[i]), 10)
+	}
+	if v.release != "" {
+		target = append(target, '-')
+		target = append(target, v.release...)
+	}
+	if v.metadata != "" {
+		target = append(target, '+')
+		target = append(target, v.metadata...)
+	}
+	return target
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v NuGetVersion) MarshalJSON() ([]byte, error) {
+	return quoteJSON(v.String()), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v NuGetVersion) MarshalText() ([]byte, error) {
+	return v.serialize(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *NuGetVersion) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, v.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *NuGetVersion) UnmarshalText(b []byte) error {
+	nv, err := NewNuGetVersion(b)
+	if err != nil {
+		return err
+	}
+	*v = nv
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *NuGetVersion) Scan(src interface{}) error {
+	return scanText(src, v.UnmarshalText, errInvalidNuGetType)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (v NuGetVersion) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// NuGetVersionPtrs represents an array with elements derived from NuGetVersion.
+// Use it to sort them, in the order NuGet does.
+type NuGetVersionPtrs []*NuGetVersion
+
+// Len implements the sort.Interface.
+func (p NuGetVersionPtrs) Len() int {
+	return len(p)
+}
+
+// Swap implements the sort.Interface.
+func (p NuGetVersionPtrs) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements the sort.Interface.
+func (p NuGetVersionPtrs) Less(i, j int) bool {
+	if p[i] == nil {
+		return false
+	} else if p[j] == nil {
+		return true
+	}
+	return p[i].Less(p[j])
+}
+
+// Sort reorders the pointers so that the NuGetVersions appear in ascending order.
+// Any nil pointers go last.
+func (p NuGetVersionPtrs) Sort() {
+	sort.Stable(p)
+}
+
+// nugetFloat is a floating version such as "1.*" or "1.0.0-beta*",
+// which NuGet resolves to the highest version that matches it.
+type nugetFloat struct {
+	str           string
+	min           NuGetVersion
+	columns       int    // The numbers that must match, such as 1 for "1.*".
+	anyPreRelease bool   // Pre-releases match, too, such as with "1.*-*".
+	floatsRelease bool   // Only pre-releases that start with releasePrefix match, besides any release.
+	releasePrefix string // Such as "beta" for "1.0.0-beta*".
+}
+
+// newNuGetFloat reads a floating version:
+// "*", "1.*", "1.2.*", or "1.2.3.*" for the highest release with the given numbers,
+// any of them followed by "-*" to include pre-releases,
+// or a version whose pre-release ends in '*', such as "1.0.0-*" or "1.0.0-beta.*".
+func newNuGetFloat(str []byte) (nugetFloat, error) {
+	f := nugetFloat{str: string(str)}
+	numbers, release := str, []byte(nil)
+	if idx := bytes.IndexByte(str, '-'); idx >= 0 {
+		numbers, release = str[:idx], str[idx+1:]
+		if star := bytes.IndexByte(release, '*'); star != len(release)-1 {
+			if star < 0 {
+				star = len(release)
+			}
+			return nugetFloat{}, newParseError(errInvalidNuGetRange, str, idx+1+star, "a trailing '*'")
+		}
+	}
+
+	var min string
+	if bytes.HasSuffix(numbers, []byte("*")) {
+		var given []byte
+		if len(numbers) > 1 {
+			if numbers[len(numbers)-2] != '.' {
+				return nugetFloat{}, newParseError(errInvalidNuGetRange, str, len(numbers)-1, "'.*' after a number")
+			}
+			given = numbers[:len(numbers)-2]
+		}
+		if release != nil && len(release) != 1 {
+			return nugetFloat{}, newParseError(errInvalidNuGetRange, str, len(numbers)+1, "'*'")
+		}
+		min = "0"
+		if len(given) > 0 {
+			f.columns = bytes.Count(given, []byte(".")) + 1
+			if f.columns > 3 {
+				return nugetFloat{}, newParseError(errInvalidNuGetRange, str, len(numbers)-2, "at most three numbers before '.*'")
+			}
+			min = string(given)
+		}
+		f.anyPreRelease = release != nil
+		if f.anyPreRelease {
+			min += "-0"
+		}
+	} else {
+		if release == nil {
+			return nugetFloat{}, newParseError(errInvalidNuGetRange, str, bytes.IndexByte(str, '*'), "'*' only at the end")
+		}
+		f.columns, f.floatsRelease = len(f.min.numbers), true
+		f.releasePrefix = string(release[:len(release)-1])
+		min = string(numbers) + "-" + f.releasePrefix
+		if len(f.releasePrefix) == 0 || f.releasePrefix[len(f.releasePrefix)-1] == '.' || f.releasePrefix[len(f.releasePrefix)-1] == '-' {
+			min += "0"
+		}
+	}
+
+	v, err := ParseNuGet(min)
+	if err != nil {
+		// The numbers and any prefix are the same in min as in str, and come first.
+		return nugetFloat{}, atOffset(err, str, 0)
+	}
+	f.min = v
+	return f, nil
+}
+
+// matches is true if v matches the floating version, as NuGet's FloatRange.Satisfies does.
+func (f nugetFloat) matches(v NuGetVersion) bool {
+	if !v.sharesNumbersWith(f.min, f.columns) {
+		return false
+	}
+	switch {
+	case !v.IsPreRelease(), f.anyPreRelease:
+		return true
+	case f.floatsRelease:
+		return len(v.release) >= len(f.releasePrefix) && strings.EqualFold(v.release[:len(f.releasePrefix)], f.releasePrefix)
+	}
+	return false
+}
+
+// NuGetRange is a version range of NuGet, such as "[1.0,2.0)" or the floating "1.*".
+type NuGetRange struct {
+	lower, upper             NuGetVersion
+	hasLower, hasUpper       bool
+	equalsLower, equalsUpper bool
+	float                    *nugetFloat
+}
+
+// NewNuGetRange reads a version range in the notation of NuGet:
+// A sole version such as "1.0" is the minimum, which is included.
+// Intervals such as "[1.0,2.0)" include a boundary if it's next to a square bracket,
+// and have no boundary on the side without version, such as "(1.0,)".
+// "[1.0]" is just 1.0.
+//
+// A floating version such as "1.*", "1.0.0-*" or "1.*-*" can stand alone or be the minimum,
+// such as in "[1.0.*, 2.0)". It is the lowest version that matches, and NuGet will pick
+// the highest one that does; see FindBestMatch.
+func NewNuGetRange(str []byte) (NuGetRange, error) {
+	var r NuGetRange
+	idx := skipSpace(str, 0)
+	end := idx + len(bytes.TrimRightFunc(str[idx:], unicode.IsSpace))
+	if idx >= end {
+		return NuGetRange{}, newParseError(errInvalidNuGetRange, str, idx, "a version or interval")
+	}
+	if str[idx] != '[' && str[idx] != '(' {
+		if err := r.setLower(str[idx:end]); err != nil {
+			return NuGetRange{}, atOffset(err, str, idx)
+		}
+		r.equalsLower = true
+		return r, nil
+	}
+
+	r.equalsLower = str[idx] == '['
+	switch str[end-1] {
+	case ']':
+		r.equalsUpper = true
+	case ')':
+	default:
+		return NuGetRange{}, newParseError(errInvalidNuGetRange, str, end, "']' or ')'")
+	}
+	if end-idx < 2 {
+		return NuGetRange{}, newParseError(errInvalidNuGetRange, str, end, "a version")
+	}
+	inner := str[idx+1 : end-1]
+	comma := bytes.IndexByte(inner, ',')
+	if comma < 0 {
+		if !r.equalsLower || !r.equalsUpper {
+			return NuGetRange{}, newParseError(errInvalidNuGetRange, str, idx, "'[' and ']' around a single version")
+		}
+		v, err := NewNuGetVersion(inner)
+		if err != nil {
+			return NuGetRange{}, atOffset(err, str, idx+1)
+		}
+		r.lower, r.hasLower, r.upper, r.hasUpper = v, true, v, true
+		return r, nil
+	}
+	if second := bytes.IndexByte(inner[comma+1:], ','); second >= 0 {
+		return NuGetRange{}, newParseError(errInvalidNuGetRange, str, idx+1+comma+1+second, "']' or ')'")
+	}
+
+	lowerStart, upperStart := skipSpace(inner, 0), skipSpace(inner, comma+1)
+	lower := bytes.TrimRightFunc(inner[lowerStart:comma], unicode.IsSpace)
+	upper := bytes.TrimRightFunc(inner[upperStart:], unicode.IsSpace)
+	if len(lower) == 0 && len(upper) == 0 {
+		return NuGetRange{}, newParseError(errInvalidNuGetRange, str, idx+1, "a version")
+	}
+	if len(lower) > 0 {
+		if err := r.setLower(lower); err != nil {
+			return NuGetRange{}, atOffset(err, str, idx+1+lowerStart)
+		}
+	}
+	if len(upper) > 0 {
+		v, err := NewNuGetVersion(upper)
+		if err != nil {
+			return NuGetRange{}, atOffset(err, str, idx+1+upperStart)
+		}
+		r.upper, r.hasUpper = v, true
+	}
+	if r.hasLower && r.hasUpper {
+		switch c := r.upper.Compare(r.lower); {
+		case c < 0:
+			return NuGetRange{}, newParseError(errInvalidNuGetRange, str, idx+1+upperStart, "an upper boundary above the lower one")
+		case c == 0 && (!r.equalsLower || !r.equalsUpper):
+			return NuGetRange{}, newParseError(errInvalidNuGetRange, str, idx+1+upperStart, "an upper boundary above the lower one, or '[' and ']'")
+		}
+	}
+	return r, nil
+}
+
+// ParseNuGetRange is NewNuGetRange for strings.
+func ParseNuGetRange(str string) (NuGetRange, error) {
+	return NewNuGetRange([]byte(str))
+}
+
+// setLower reads the lower boundary, which can be a floating version.
+func (r *NuGetRange) setLower(str []byte) error {
+	if bytes.IndexByte(str, '*') >= 0 {
+		f, err := newNuGetFloat(str)
+		if err != nil {
+			return err
+		}
+		r.lower, r.hasLower, r.float = f.min, true, &f
+		return nil
+	}
+	v, err := NewNuGetVersion(str)
+	if err != nil {
+		return err
+	}
+	r.lower, r.hasLower = v, true
+	return nil
+}
+
+// Contains returns true if the NuGetVersion is inside this range, as NuGet's Satisfies does.
+//
+// If in doubt use IsSatisfiedBy.
+func (r NuGetRange) Contains(v NuGetVersion) bool {
+	if r.hasLower {
+		c := v.Compare(r.lower)
+		if c < 0 || (c == 0 && !r.equalsLower) {
+			return false
+		}
+	}
+	if r.hasUpper {
+		c := v.Compare(r.upper)
+		if c > 0 || (c == 0 && !r.equalsUpper) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSatisfiedBy works like Contains,
+// but rejects pre-releases unless a boundary is one, such as in "[1.0.0-beta, 2.0)" or "1.0.0-*".
+func (r NuGetRange) IsSatisfiedBy(v NuGetVersion) bool {
+	if !r.Contains(v) {
+		return false
+	}
+	return !v.IsPreRelease() || (r.hasLower && r.lower.IsPreRelease()) || (r.hasUpper && r.upper.IsPreRelease())
+}
+
+// IsFloating is true if the minimum is a floating version, such as in "1.*" or "[1.0.0-*, 2.0)".
+func (r NuGetRange) IsFloating() bool {
+	return r.float != nil
+}
+
+// FindBestMatch returns the version NuGet would pick, or nil if none is in the range.
+//
+// That is the lowest one, unless the range floats: then it is the highest of those
+// that match the floating version, or if none does the lowest above it.
+func (r NuGetRange) FindBestMatch(versions NuGetVersionPtrs) *NuGetVersion {
+	var best *NuGetVersion
+	for _, v := range versions {
+		if v != nil && r.isBetter(best, v) {
+			best = v
+		}
+	}
+	return best
+}
+
+// isBetter is true if considering is a better pick than current, as in NuGet's VersionRange.IsBetter.
+func (r NuGetRange) isBetter(current, considering *NuGetVersion) bool {
+	switch {
+	case !r.Contains(*considering):
+		return false
+	case current == nil:
+		return true
+	case r.float == nil:
+		return considering.Less(current)
+	}
+
+	currentMatches, consideringMatches := r.float.matches(*current), r.float.matches(*considering)
+	switch {
+	case currentMatches && consideringMatches:
+		return current.Less(considering)
+	case currentMatches != consideringMatches:
+		return consideringMatches
+	}
+	currentBelow, consideringBelow := current.Less(&r.float.min), considering.Less(&r.float.min)
+	switch {
+	case currentBelow && consideringBelow:
+		return current.Less(considering)
+	case currentBelow != consideringBelow:
+		return currentBelow
+	}
+	return considering.Less(current)
+}
+
+// String returns the range as NuGet normalizes it, such as "[1.0.0, 2.0.0)",
+// with any floating version as it has been read.
+func (r NuGetRange) String() string {
+	lower := r.lower.String()
+	if r.float != nil {
+		lower = r.float.str
+	}
+	if r.hasLower && r.hasUpper && r.equalsLower && r.equalsUpper && r.lower.Compare(r.upper) == 0 {
+		return "[" + lower + "]"
+	}
+
+	var b strings.Builder
+	if r.equalsLower {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if r.hasLower {
+		b.WriteString(lower)
+	}
+	b.WriteString(", ")
+	if r.hasUpper {
+		b.WriteString(r.upper.String())
+	}
+	if r.equalsUpper {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
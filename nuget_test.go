@@ -0,0 +1,263 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParseNuGet(str string) NuGetVersion {
+	v, err := ParseNuGet(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestNewNuGetVersion(t *testing.T) {
+	Convey("NewNuGetVersion reads, and normalizes…", t, FailureContinues, func() {
+		for str, normalized := range map[string]string{
+			"1":                       "1.0.0",
+			"1.0":                     "1.0.0",
+			"1.2.3.4":                 "1.2.3.4",
+			"1.2.3.0":                 "1.2.3",
+			"01.002.3":                "1.2.3",
+			" 1.0.0-Beta.2+sha.1 ":    "1.0.0-Beta.2+sha.1",
+			"1.0.0.1-rc-1":            "1.0.0.1-rc-1",
+			"2.0+20130313144700.a-b1": "2.0.0+20130313144700.a-b1",
+		} {
+			Convey(str, func() {
+				v, err := ParseNuGet(str)
+				So(err, ShouldBeNil)
+				So(v.String(), ShouldEqual, normalized)
+			})
+		}
+	})
+
+	Convey("NewNuGetVersion rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":          0,
+			"a":         0,
+			"v1.0":      0,
+			"1.":        2,
+			"1.2.3.4.5": 7,
+			"1.0-":      4,
+			"1.0-a..b":  6,
+			"1.0+":      4,
+			"1.0_1":     3,
+			"1.0-a+b_c": 7,
+		} {
+			Convey(str, func() {
+				_, err := ParseNuGet(str)
+				So(errors.Is(err, errInvalidNuGetVersion), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
+
+func TestNuGetVersionOrder(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-BETA", "1.0.0-beta.2", "1.0.0-beta.11",
+		"1.0.0-rc.1", "1.0.0", "1.0.0.1", "1.0.1", "1.1.0", "2.0.0",
+	}
+
+	Convey("NuGetVersions are ordered as by NuGet", t, func() {
+		for i := 0; i+1 < len(ordered); i++ {
+			a, b := mustParseNuGet(ordered[i]), mustParseNuGet(ordered[i+1])
+			So(a.Compare(b), ShouldEqual, -1)
+			So(b.Compare(a), ShouldEqual, 1)
+		}
+		for a, b := range map[string]string{
+			"1.0.0-BETA": "1.0.0-beta",
+			"1.0":        "1.0.0.0",
+			"1.0.0+a":    "1.0.0+b",
+		} {
+			So(mustParseNuGet(a).Compare(mustParseNuGet(b)), ShouldEqual, 0)
+		}
+
+		Convey("also by NuGetVersionPtrs, nil last", func() {
+			ptrs := NuGetVersionPtrs{nil}
+			for i := len(ordered) - 1; i >= 0; i-- {
+				v := mustParseNuGet(ordered[i])
+				ptrs = append(ptrs, &v)
+			}
+			ptrs.Sort()
+			for i := range ordered {
+				So(ptrs[i].String(), ShouldEqual, ordered[i])
+			}
+			So(ptrs[len(ordered)], ShouldBeNil)
+		})
+	})
+
+	Convey("NuGetVersion is read from packages.lock.json…", t, FailureContinues, func() {
+		var lock struct {
+			Dependencies map[string]map[string]struct {
+				Resolved NuGetVersion
+			}
+		}
+		So(json.Unmarshal([]byte(`{"version": 1, "dependencies": {"net8.0": {
+			"Newtonsoft.Json": {"type": "Direct", "requested": "[13.0.3, )", "resolved": "13.0.3.0"},
+			"Serilog": {"type": "Transitive", "resolved": "4.0.0-Dev-02108+sha.1"}
+		}}}`), &lock), ShouldBeNil)
+		packages := lock.Dependencies["net8.0"]
+
+		Convey("and written normalized, without a zero fourth number", func() {
+			out, err := json.Marshal(packages["Newtonsoft.Json"].Resolved)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `"13.0.3"`)
+		})
+		Convey("keeping the case of the pre-release, and the metadata", func() {
+			text, err := packages["Serilog"].Resolved.MarshalText()
+			So(err, ShouldBeNil)
+			So(string(text), ShouldEqual, "4.0.0-Dev-02108+sha.1")
+			So(packages["Serilog"].Resolved.Compare(mustParseNuGet("4.0.0-dev-02108")), ShouldEqual, 0)
+		})
+	})
+
+	Convey("NuGetVersion is scanned from databases", t, func() {
+		v := mustParseNuGet("1.2.3.4-Beta.2+sha.1")
+		val, _ := v.Value()
+		var back NuGetVersion
+		So(back.Scan(val), ShouldBeNil)
+		So(back, ShouldResemble, v)
+		So(back.Scan(" 1.0 "), ShouldBeNil)
+		So(back.String(), ShouldEqual, "1.0.0")
+		So(errors.Is(back.Scan([]byte("v1.0")), errInvalidNuGetVersion), ShouldBeTrue)
+		So(back.Scan(3.14), ShouldEqual, errInvalidNuGetType)
+	})
+}
+
+func TestNuGetRange(t *testing.T) {
+	Convey("NuGetRange contains…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"1.0":               {"1.0": true, "0.9": false, "2.0": true},
+			"[1.0,2.0)":         {"1.0": true, "1.9.9": true, "2.0": false, "2.0.0-beta": true},
+			"(1.0,)":            {"1.0": false, "1.0.0.1": true},
+			"[1.0]":             {"1.0.0": true, "1.0.0.1": false, "1.0.0-rc": false},
+			"(,1.0]":            {"1.0": true, "0.1": true, "1.0.1": false},
+			"(,1.0)":            {"1.0": false, "0.9": true},
+			" [ 1.0 , 2.0 ] ":   {"2.0": true, "2.0.0.1": false},
+			"[1.0-Beta,1.0-rc]": {"1.0.0-beta": true, "1.0.0-RC": true, "1.0.0-alpha": false},
+			"1.*":               {"1.0": true, "5.0": true, "0.9": false},
+			"1.0.0-*":           {"1.0.0-alpha": true, "1.0.0": true, "0.9.9": false},
+			"[1.0.*, 2.0)":      {"1.5": true, "2.0": false},
+		} {
+			r, err := ParseNuGetRange(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(r.Contains(mustParseNuGet(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("IsSatisfiedBy excludes pre-releases…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"[1.0,2.0)":         {"1.5.0-beta": false, "1.5.0": true},
+			"[1.0.0-beta, 2.0)": {"1.5.0-beta": true},
+			"(, 2.0.0-rc]":      {"1.5.0-beta": true},
+			"1.0.0-*":           {"1.0.1-rc": true},
+			"1.*-*":             {"1.2.0-alpha": true},
+			"1.*":               {"1.2.0-alpha": false},
+		} {
+			r, err := ParseNuGetRange(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(r.IsSatisfiedBy(mustParseNuGet(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("FindBestMatch picks as NuGet does…", t, FailureContinues, func() {
+		var versions NuGetVersionPtrs
+		for _, str := range []string{
+			"2.1.0", "0.9.0", "1.0.0", "1.0.0-beta", "1.1.0", "1.2.0-rc1", "1.2.0", "2.0.0-beta", "2.0.0",
+		} {
+			v := mustParseNuGet(str)
+			versions = append(versions, &v)
+		}
+		versions = append(versions, nil)
+
+		for str, expected := range map[string]string{
+			"1.0":              "1.0.0",
+			"[1.0,2.0)":        "1.0.0",
+			"(0.0.0, 1.0.0)":   "0.9.0",
+			"1.*":              "1.2.0",
+			"1.*-*":            "1.2.0",
+			"1.2.*-*":          "1.2.0",
+			"*":                "2.1.0",
+			"*-*":              "2.1.0",
+			"2.0.0-*":          "2.0.0",
+			"2.0.0-beta*":      "2.0.0",
+			"[1.1.*, 1.1.5)":   "1.1.0",
+			"[0.8.*, )":        "0.9.0",
+			"[1.2.0-*, 1.2.0]": "1.2.0",
+		} {
+			Convey(str, func() {
+				r, err := ParseNuGetRange(str)
+				So(err, ShouldBeNil)
+				So(r.FindBestMatch(versions).String(), ShouldEqual, expected)
+			})
+		}
+
+		Convey("nil if none is in the range", func() {
+			r, _ := ParseNuGetRange("3.*")
+			So(r.FindBestMatch(versions), ShouldBeNil)
+		})
+	})
+
+	Convey("NuGetRange is written normalized", t, FailureContinues, func() {
+		for str, normalized := range map[string]string{
+			"1.0":          "[1.0.0, )",
+			"[1.0,2.0)":    "[1.0.0, 2.0.0)",
+			"[1.0]":        "[1.0.0]",
+			"(,1.0]":       "(, 1.0.0]",
+			"1.*":          "[1.*, )",
+			"[1.0.*, 2.0)": "[1.0.*, 2.0.0)",
+		} {
+			r, err := ParseNuGetRange(str)
+			So(err, ShouldBeNil)
+			So(r.String(), ShouldEqual, normalized)
+			So(r.IsFloating(), ShouldEqual, str[len(str)-1] == '*' || str == "[1.0.*, 2.0)")
+		}
+	})
+
+	Convey("NewNuGetRange rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":              0,
+			"(1.0)":         0,
+			"[1.0":          4,
+			"[1.0,2.0,3.0]": 8,
+			"[2.0,1.0]":     5,
+			"(1.0,1.0]":     5,
+			"[,]":           1,
+			"1.*.3":         2,
+			"1*":            1,
+			"[1.0, 2.*)":    8,
+			"[1.0.*]":       5,
+			"1.0.0-beta*x":  10,
+			"1.2.3.4.*":     7,
+			"1.*-beta*":     4,
+		} {
+			Convey(str, func() {
+				_, err := ParseNuGetRange(str)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Input, ShouldEqual, str)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
@@ -0,0 +1,266 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Errors that are thrown when reading a PacmanVersion.
+const (
+	errInvalidPacmanVersion InvalidStringValue = "Given string does not resemble a version of an Arch package"
+	errInvalidPacmanType    InvalidStringValue = "Cannot read this type into a PacmanVersion"
+)
+
+// PacmanVersion is the "epoch:pkgver-pkgrel" of an Arch Linux package, such as "1:2.3.4-1".
+type PacmanVersion struct {
+	epoch    int
+	hasEpoch bool
+	version  string
+	release  string
+}
+
+// NewPacmanVersion reads a version of an Arch package, in which both the epoch and pkgrel are optional.
+//
+// The pkgver consists of letters, digits, and any of "._+~", and the pkgrel is a number
+// that can have a minor part, such as "1.1".
+func NewPacmanVersion(str []byte) (PacmanVersion, error) {
+	var v PacmanVersion
+	versionStart, versionEnd := 0, len(str)
+	if idx := bytes.IndexByte(str, ':'); idx >= 0 {
+		if n := countDigits(str[:idx]); n < idx || n == 0 {
+			return PacmanVersion{}, newParseError(errInvalidPacmanVersion, str, n, "a number as epoch")
+		}
+		epoch, err := strconv.ParseInt(string(str[:idx]), 10, 32)
+		if err != nil {
+			return PacmanVersion{}, newParseError(errInvalidPacmanVersion, str, 0, "an epoch that fits into 31 bits")
+		}
+		v.epoch, v.hasEpoch, versionStart = int(epoch), true, idx+1
+	}
+	if idx := bytes.LastIndexByte(str, '-'); idx >= versionStart {
+		release := str[idx+1:]
+		n := countDigits(release)
+		if n > 0 && n < len(release) && release[n] == '.' {
+			if m := countDigits(release[n+1:]); m > 0 {
+				n += 1 + m
+			}
+		}
+		if n == 0 || n < len(release) {
+			return PacmanVersion{}, newParseError(errInvalidPacmanVersion, str, idx+1+n, "a number as pkgrel, such as 1 or 1.1")
+		}
+		v.release, versionEnd = string(release), idx
+	}
+	for i := versionStart; i < versionEnd; i++ {
+		if !isNumeric(str[i]) && !isSmallLetter(str[i]|0x20) && strings.IndexByte("._+~", str[i]) < 0 {
+			return PacmanVersion{}, newParseError(errInvalidPacmanVersion, str, i, "a letter, digit, or any of ._+~")
+		}
+	}
+	if versionStart == versionEnd {
+		return PacmanVersion{}, newParseError(errInvalidPacmanVersion, str, versionStart, "a pkgver")
+	}
+	v.version = string(str[versionStart:versionEnd])
+	return v, nil
+}
+
+// ParsePacman is NewPacmanVersion for strings.
+func ParsePacman(str string) (PacmanVersion, error) {
+	return NewPacmanVersion([]byte(str))
+}
+
+// Epoch returns the epoch, which is 0 if none has been given.
+func (v PacmanVersion) Epoch() int {
+	return v.epoch
+}
+
+// Version returns the pkgver, such as "2.3.4" in "1:2.3.4-1".
+func (v PacmanVersion) Version() string {
+	return v.version
+}
+
+// Release returns the pkgrel, such as "1" in "1:2.3.4-1",
+// or the empty string if there's none.
+func (v PacmanVersion) Release() string {
+	return v.release
+}
+
+// Compare returns the signum of the difference between v and o, as vercmp of pacman orders them:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// Epochs are compared as numbers, then the pkgvers, then the pkgrels if both have one,
+// by the rpmvercmp of libalpm: Like in RPMVersion the strings are split into runs of digits
+// or letters, but a longer separator is greater, and there's no special meaning to '~' or '^'.
+// A trailing run of letters makes a version lower: 1.0a < 1.0 < 1.0.a < 1.0.1.
+func (v PacmanVersion) Compare(o PacmanVersion) int {
+	if v.epoch != o.epoch {
+		return signum(v.epoch - o.epoch)
+	}
+	if c := alpmvercmp(v.version, o.version); c != 0 || v.release == "" || o.release == "" {
+		return c
+	}
+	return alpmvercmp(v.release, o.release)
+}
+
+// Less is a convenience function for sorting.
+func (v *PacmanVersion) Less(o *PacmanVersion) bool {
+	return v.Compare(*o) < 0
+}
+
+// alpmvercmp compares two pkgvers, or pkgrels, as libalpm does.
+func alpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	isLetter := func(ch byte) bool { return isSmallLetter(ch | 0x20) }
+	isSeparator := func(ch byte) bool { return !isNumeric(ch) && !isLetter(ch) }
+
+	var i, j int // Starts of the current runs.
+	var x, y int // Ends of the previous runs.
+	for i < len(a) && j < len(b) {
+		for i < len(a) && isSeparator(a[i]) {
+			i++
+		}
+		for j < len(b) && isSeparator(b[j]) {
+			j++
+		}
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+		// A longer separator is greater.
+		if i-x != j-y {
+			return signum((i - x) - (j - y))
+		}
+
+		// Take a run of digits, or of letters.
+		isRun, isNumber := isNumeric, isNumeric(a[i])
+		if !isNumber {
+			isRun = isLetter
+		}
+		x, y = i, j
+		for x < len(a) && isRun(a[x]) {
+			x++
+		}
+		for y < len(b) && isRun(b[y]) {
+			y++
+		}
+		if y == j { // Numbers are newer than letters.
+			if isNumber {
+				return 1
+			}
+			return -1
+		}
+
+		var c int
+		if isNumber {
+			c = compareDecimals(a[i:x], b[j:y])
+		} else {
+			c = strings.Compare(a[i:x], b[j:y])
+		}
+		if c != 0 {
+			return c
+		}
+		i, j = x, y
+	}
+
+	// Whatever has more left is greater, unless that's a run of letters.
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i >= len(a) && !isLetter(b[j]), i < len(a) && isLetter(a[i]):
+		return -1
+	}
+	return 1
+}
+
+// serialize returns the version as it has been read.
+func (v PacmanVersion) serialize() []byte {
+	target := make([]byte, 0, len(v.version)+len(v.release)+16)
+	if v.hasEpoch {
+		target = strconv.AppendInt(target, int64(v.epoch), 10)
+		target = append(target, ':')
+	}
+	target = append(target, v.version...)
+	if v.release != "" {
+		target = append(target, '-')
+		target = append(target, v.release...)
+	}
+	return target
+}
+
+// String returns the string representation of v.
+func (v PacmanVersion) String() string {
+	return string(v.serialize())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v PacmanVersion) MarshalJSON() ([]byte, error) {
+	return quoteJSON(v.String()), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v PacmanVersion) MarshalText() ([]byte, error) {
+	return v.serialize(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *PacmanVersion) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, v.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *PacmanVersion) UnmarshalText(b []byte) error {
+	pv, err := NewPacmanVersion(b)
+	if err != nil {
+		return err
+	}
+	*v = pv
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *PacmanVersion) Scan(src interface{}) error {
+	return scanText(src, v.UnmarshalText, errInvalidPacmanType)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (v PacmanVersion) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// PacmanVersionPtrs represents an array with elements derived from PacmanVersion.
+// Use it to sort them, in the order pacman does.
+type PacmanVersionPtrs []*PacmanVersion
+
+// Len implements the sort.Interface.
+func (p PacmanVersionPtrs) Len() int {
+	return len(p)
+}
+
+// Swap implements the sort.Interface.
+func (p PacmanVersionPtrs) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements the sort.Interface.
+func (p PacmanVersionPtrs) Less(i, j int) bool {
+	if p[i] == nil {
+		return false
+	} else if p[j] == nil {
+		return true
+	}
+	return p[i].Less(p[j])
+}
+
+// Sort reorders the pointers so that the PacmanVersions appear in ascending order.
+// Any nil pointers go last.
+func (p PacmanVersionPtrs) Sort() {
+	sort.Stable(p)
+}
@@ -0,0 +1,163 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParsePacman(str string) PacmanVersion {
+	v, err := ParsePacman(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestNewPacmanVersion(t *testing.T) {
+	Convey("NewPacmanVersion reads…", t, FailureContinues, func() {
+		for str, parts := range map[string][3]string{
+			"1:2.3.4-1":          {"1", "2.3.4", "1"},
+			"2.3.4-1.1":          {"0", "2.3.4", "1.1"},
+			"0:1.0":              {"0", "1.0", ""},
+			"1.0+r3+gabc123-2":   {"0", "1.0+r3+gabc123", "2"},
+			"20240101.abc_def-1": {"0", "20240101.abc_def", "1"},
+			"1.0~rc1-1":          {"0", "1.0~rc1", "1"},
+		} {
+			Convey(str, func() {
+				v, err := ParsePacman(str)
+				So(err, ShouldBeNil)
+				So(v.String(), ShouldEqual, str)
+				So(v.Version(), ShouldEqual, parts[1])
+				So(v.Release(), ShouldEqual, parts[2])
+				if parts[0] == "1" {
+					So(v.Epoch(), ShouldEqual, 1)
+				} else {
+					So(v.Epoch(), ShouldEqual, 0)
+				}
+			})
+		}
+	})
+
+	Convey("NewPacmanVersion rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":          0,
+			"a:1.0":     0,
+			"1:":        2,
+			"1.0-":      4,
+			"1.0-a":     4,
+			"1.0-1.":    5,
+			"1.0-1.1.1": 7,
+			"-1":        0,
+			"1.0/2-1":   3,
+		} {
+			Convey(str, func() {
+				_, err := ParsePacman(str)
+				So(errors.Is(err, errInvalidPacmanVersion), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
+
+func TestPacmanVersionOrder(t *testing.T) {
+	Convey("PacmanVersions are ordered as by vercmp…", t, FailureContinues, func() {
+		// These are from pacman's test/util/vercmptest.sh.
+		for _, tc := range []struct {
+			a, b     string
+			expected int
+		}{
+			{"1.5.0", "1.5.0", 0},
+			{"1.5.1", "1.5.0", 1},
+			{"1.5.1", "1.5", 1},
+			{"1.5.0-1", "1.5.0-2", -1},
+			{"1.5.0-2", "1.5.1-1", -1},
+			{"1.5-2", "1.5.1-1", -1},
+			{"1.5", "1.5-1", 0},
+			{"1.0-1", "1.1", -1},
+			{"1.5b-1", "1.5-1", -1},
+			{"1.5b", "1.5", -1},
+			{"1.5b", "1.5.1", -1},
+			{"1.0a", "1.0alpha", -1},
+			{"1.0alpha", "1.0b", -1},
+			{"1.0b", "1.0beta", -1},
+			{"1.0beta", "1.0rc", -1},
+			{"1.0rc", "1.0", -1},
+			{"1.5.a", "1.5", 1},
+			{"1.5.b", "1.5.a", 1},
+			{"1.5.1", "1.5.b", 1},
+			{"1.5.b-1", "1.5.b", 0},
+			{"1.5-1", "1.5.b", -1},
+			{"2.0", "2_0", 0},
+			{"2.0_a", "2_0.a", 0},
+			{"2.0a", "2.0.a", -1},
+			{"2___a", "2_a", 1},
+			{"0:1.0", "0:1.1", -1},
+			{"1:1.0", "0:1.1", 1},
+			{"1:1.0", "2:1.1", -1},
+			{"1:1.0", "0:1.0-1", 1},
+			{"0:1.0", "1.0", 0},
+			{"1:1.1", "1.11", 1},
+			{"1.0~rc1", "1.0", 1},
+			{"1.010", "1.10", 0},
+		} {
+			Convey(tc.a+" and "+tc.b, func() {
+				a, b := mustParsePacman(tc.a), mustParsePacman(tc.b)
+				So(a.Compare(b), ShouldEqual, tc.expected)
+				So(b.Compare(a), ShouldEqual, -tc.expected)
+			})
+		}
+	})
+
+	Convey("PacmanVersionPtrs sort, nil last", t, func() {
+		ordered := []string{"1.0a", "1.0-1", "1.0-2", "1.0.a-1", "1.0.1-1", "1:0.1-1"}
+		ptrs := PacmanVersionPtrs{nil}
+		for i := len(ordered) - 1; i >= 0; i-- {
+			v := mustParsePacman(ordered[i])
+			ptrs = append(ptrs, &v)
+		}
+		ptrs.Sort()
+		for i := range ordered {
+			So(ptrs[i].String(), ShouldEqual, ordered[i])
+		}
+		So(ptrs[len(ordered)], ShouldBeNil)
+	})
+
+	Convey("PacmanVersion is written as it's read…", t, FailureContinues, func() {
+		Convey("keeping an explicit zero epoch", func() {
+			v := mustParsePacman("0:1.0-1")
+			out, err := json.Marshal(v)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `"0:1.0-1"`)
+			So(v.Compare(mustParsePacman("1.0-1")), ShouldEqual, 0)
+		})
+
+		Convey("with a pkgrel that has a minor part", func() {
+			v := mustParsePacman("1:2.3.4+r12~gabc-1.1")
+			text, err := v.MarshalText()
+			So(err, ShouldBeNil)
+			So(string(text), ShouldEqual, "1:2.3.4+r12~gabc-1.1")
+			var back PacmanVersion
+			So(json.Unmarshal(append(append([]byte{'"'}, text...), '"'), &back), ShouldBeNil)
+			So(back, ShouldResemble, v)
+		})
+	})
+
+	Convey("PacmanVersion is scanned from databases, such as the %VERSION% of a local db", t, func() {
+		v := mustParsePacman("1:2.3.4-1")
+		val, _ := v.Value()
+		var back PacmanVersion
+		So(back.Scan(val), ShouldBeNil)
+		So(back, ShouldResemble, v)
+		So(errors.Is(back.Scan([]byte("1.0-1.")), errInvalidPacmanVersion), ShouldBeTrue)
+		So(back.Scan(3.14), ShouldEqual, errInvalidPacmanType)
+	})
+}
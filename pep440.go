@@ -0,0 +1,626 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Errors that are thrown when reading a PEP440Version or PEP440SpecifierSet.
+const (
+	errInvalidPEP440Version   InvalidStringValue = "Given string does not resemble a PEP 440 version"
+	errInvalidPEP440Type      InvalidStringValue = "Cannot read this type into a PEP440Version"
+	errInvalidPEP440Specifier InvalidStringValue = "Given string does not resemble a PEP 440 version specifier"
+)
+
+// pep440PreReleases are the spellings of pre-releases with their kind,
+// as index into pep440PreReleaseKinds. Longer spellings go before any prefix of theirs.
+var pep440PreReleases = [...]struct {
+	label string
+	kind  int
+}{
+	{"alpha", 0}, {"a", 0}, {"beta", 1}, {"b", 1}, {"preview", 2}, {"pre", 2}, {"rc", 2}, {"c", 2},
+}
+
+// pep440PreReleaseKinds are the normalized pre-releases, in ascending order.
+var pep440PreReleaseKinds = [...]string{"a", "b", "rc"}
+
+// PEP440Version is a version of a Python package, such as "2!1.0rc1.post2.dev3+ubuntu.1",
+// as defined by PEP 440.
+//
+// Unlike Version this has any number of release components, which can be of arbitrary length,
+// and can be a pre-, post- and development release at once.
+type PEP440Version struct {
+	epoch   int
+	release []string // Numbers without leading zeroes.
+	preKind int      // Index into pep440PreReleaseKinds, or -1 if this is no pre-release.
+	pre     string
+	hasPost bool
+	post    string
+	hasDev  bool
+	dev     string
+	local   []string
+}
+
+// NewPEP440Version reads a version in its normalized form, or any of the alternative spellings
+// that PEP 440 permits, such as "v1.0-ALPHA.1", "1.0-1" for "1.0.post1", or "1.0dev" for "1.0.dev0".
+// Surrounding whitespace is ignored.
+func NewPEP440Version(str []byte) (PEP440Version, error) {
+	v := PEP440Version{preKind: -1}
+	idx := len(str) - len(bytes.TrimLeftFunc(str, unicode.IsSpace))
+	s := bytes.TrimRightFunc(str, unicode.IsSpace)
+	if idx < len(s) && s[idx]|0x20 == 'v' {
+		idx++
+	}
+
+	if n := countDigits(s[idx:]); n > 0 && idx+n < len(s) && s[idx+n] == '!' {
+		epoch, err := strconv.ParseInt(string(s[idx:idx+n]), 10, 32)
+		if err != nil {
+			return PEP440Version{}, newParseError(errInvalidPEP440Version, str, idx, "an epoch that fits into 31 bits")
+		}
+		v.epoch = int(epoch)
+		idx += n + 1
+	}
+	for {
+		n := countDigits(s[idx:])
+		if n == 0 {
+			return PEP440Version{}, newParseError(errInvalidPEP440Version, str, idx, "a digit")
+		}
+		v.release = append(v.release, trimPEP440Number(s[idx:idx+n]))
+		idx += n
+		if idx+1 >= len(s) || s[idx] != '.' || !isNumeric(s[idx+1]) {
+			break
+		}
+		idx++
+	}
+
+	if next := skipPEP440Separator(s, idx); next < len(s) {
+		for _, pre := range pep440PreReleases {
+			if hasPrefixFold(s[next:], pre.label) {
+				v.preKind = pre.kind
+				v.pre, idx = readPEP440Number(s, next+len(pre.label))
+				break
+			}
+		}
+	}
+	if idx+1 < len(s) && s[idx] == '-' && isNumeric(s[idx+1]) {
+		v.hasPost = true
+		v.post, idx = readPEP440Number(s, idx+1)
+	} else if next := skipPEP440Separator(s, idx); next < len(s) {
+		for _, label := range [...]string{"post", "rev", "r"} {
+			if hasPrefixFold(s[next:], label) {
+				v.hasPost = true
+				v.post, idx = readPEP440Number(s, next+len(label))
+				break
+			}
+		}
+	}
+	if next := skipPEP440Separator(s, idx); hasPrefixFold(s[next:], "dev") {
+		v.hasDev = true
+		v.dev, idx = readPEP440Number(s, next+len("dev"))
+	}
+
+	if idx < len(s) && s[idx] == '+' {
+		for {
+			idx++
+			n := 0
+			for idx+n < len(s) && (isNumeric(s[idx+n]) || isSmallLetter(s[idx+n]|0x20)) {
+				n++
+			}
+			if n == 0 {
+				return PEP440Version{}, newParseError(errInvalidPEP440Version, str, idx, "a letter or digit")
+			}
+			segment := s[idx : idx+n]
+			if countDigits(segment) == n {
+				v.local = append(v.local, trimPEP440Number(segment))
+			} else {
+				v.local = append(v.local, strings.ToLower(string(segment)))
+			}
+			idx += n
+			if idx >= len(s) || (s[idx] != '.' && s[idx] != '-' && s[idx] != '_') {
+				break
+			}
+		}
+	}
+
+	if idx < len(s) {
+		return PEP440Version{}, newParseError(errInvalidPEP440Version, str, idx, "a pre-, post-, or development release, '+', or the end")
+	}
+	return v, nil
+}
+
+// ParsePEP440 is NewPEP440Version for strings.
+func ParsePEP440(str string) (PEP440Version, error) {
+	return NewPEP440Version([]byte(str))
+}
+
+// skipPEP440Separator returns the index after the optional '.', '-' or '_' at idx.
+func skipPEP440Separator(str []byte, idx int) int {
+	if idx < len(str) && (str[idx] == '.' || str[idx] == '-' || str[idx] == '_') {
+		return idx + 1
+	}
+	return idx
+}
+
+// readPEP440Number reads the optional number, with an optional separator before it,
+// that follows a pre-, post-, or development release at idx.
+// An omitted number is 0.
+func readPEP440Number(str []byte, idx int) (string, int) {
+	if next := skipPEP440Separator(str, idx); next < len(str) && isNumeric(str[next]) {
+		idx = next
+	}
+	n := countDigits(str[idx:])
+	return trimPEP440Number(str[idx : idx+n]), idx + n
+}
+
+func trimPEP440Number(str []byte) string {
+	if trimmed := bytes.TrimLeft(str, "0"); len(trimmed) > 0 {
+		return string(trimmed)
+	}
+	return "0"
+}
+
+// hasPrefixFold is true if str begins with prefix, which must be in lower case,
+// regardless of the case of str.
+func hasPrefixFold(str []byte, prefix string) bool {
+	if len(str) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if str[i]|0x20 != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Epoch returns the epoch, which is 0 if none has been given.
+func (v PEP440Version) Epoch() int {
+	return v.epoch
+}
+
+// Local returns the local version label, such as "ubuntu.1" in "1.0+ubuntu.1",
+// or the empty string if there's none.
+func (v PEP440Version) Local() string {
+	return strings.Join(v.local, ".")
+}
+
+// IsPreRelease is true for pre-releases and development releases,
+// such as 1.0a1 and 1.0.dev2, but also 1.0.post1.dev3.
+func (v PEP440Version) IsPreRelease() bool {
+	return v.preKind >= 0 || v.hasDev
+}
+
+// IsPostRelease is true for versions such as 1.0.post1 and 1.0-1.
+func (v PEP440Version) IsPostRelease() bool {
+	return v.hasPost
+}
+
+// public returns v without any local version label.
+func (v PEP440Version) public() PEP440Version {
+	v.local = nil
+	return v
+}
+
+// base returns the epoch and release of v.
+func (v PEP440Version) base() PEP440Version {
+	return PEP440Version{epoch: v.epoch, release: v.release, preKind: -1}
+}
+
+// Compare returns the signum of the difference between v and o, as PEP 440 orders them:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// After epoch and release, which is padded with zeroes, come development releases,
+// then pre-releases by kind and number, the release itself, and then post-releases:
+// 1.0.dev1 < 1.0a1.dev1 < 1.0a1 < 1.0a1.post1 < 1.0rc1 < 1.0 < 1.0.post1.dev1 < 1.0.post1.
+// Any local version label sorts after its absence.
+func (v PEP440Version) Compare(o PEP440Version) int {
+	if v.epoch != o.epoch {
+		return signum(v.epoch - o.epoch)
+	}
+	for i := 0; i < len(v.release) || i < len(o.release); i++ {
+		a, b := "0", "0"
+		if i < len(v.release) {
+			a = v.release[i]
+		}
+		if i < len(o.release) {
+			b = o.release[i]
+		}
+		if c := compareDecimals(a, b); c != 0 {
+			return c
+		}
+	}
+
+	if c := signum(v.preRank() - o.preRank()); c != 0 {
+		return c
+	}
+	if c := compareDecimals(v.pre, o.pre); c != 0 {
+		return c
+	}
+	if v.hasPost != o.hasPost {
+		if v.hasPost {
+			return 1
+		}
+		return -1
+	}
+	if c := compareDecimals(v.post, o.post); c != 0 {
+		return c
+	}
+	if v.hasDev != o.hasDev {
+		if v.hasDev {
+			return -1
+		}
+		return 1
+	}
+	if c := compareDecimals(v.dev, o.dev); c != 0 {
+		return c
+	}
+
+	// Numeric segments of local version labels are greater than alphanumeric ones.
+	for i := 0; i < len(v.local) && i < len(o.local); i++ {
+		a, b := v.local[i], o.local[i]
+		aIsNumber, bIsNumber := countDigits([]byte(a)) == len(a), countDigits([]byte(b)) == len(b)
+		var c int
+		switch {
+		case aIsNumber && bIsNumber:
+			c = compareDecimals(a, b)
+		case aIsNumber:
+			c = 1
+		case bIsNumber:
+			c = -1
+		default:
+			c = strings.Compare(a, b)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return signum(len(v.local) - len(o.local))
+}
+
+// preRank orders the kinds of pre-releases, with a sole development release below them all,
+// and no pre-release above.
+func (v PEP440Version) preRank() int {
+	switch {
+	case v.preKind >= 0:
+		return v.preKind
+	case v.hasDev && !v.hasPost:
+		return -1
+	}
+	return len(pep440PreReleaseKinds)
+}
+
+// Less is a convenience function for sorting.
+func (v *PEP440Version) Less(o *PEP440Version) bool {
+	return v.Compare(*o) < 0
+}
+
+// serialize returns the normalized representation.
+func (v PEP440Version) serialize() []byte {
+	target := make([]byte, 0, 32)
+	if v.epoch != 0 {
+		target = strconv.AppendInt(target, int64(v.epoch), 10)
+		target = append(target, '!')
+	}
+	for i, number := range v.release {
+		if i > 0 {
+			target = append(target, '.')
+		}
+		target = append(target, number...)
+	}
+	if v.preKind >= 0 {
+		target = append(target, pep440PreReleaseKinds[v.preKind]...)
+		target = append(target, v.pre...)
+	}
+	if v.hasPost {
+		target = append(target, ".post"...)
+		target = append(target, v.post...)
+	}
+	if v.hasDev {
+		target = append(target, ".dev"...)
+		target = append(target, v.dev...)
+	}
+	if len(v.local) > 0 {
+		target = append(target, '+')
+		target = append(target, v.Local()...)
+	}
+	return target
+}
+
+// String returns the normalized string representation of v, such as "1.0a1.post0" for "1.0-alpha1-0".
+func (v PEP440Version) String() string {
+	return string(v.serialize())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v PEP440Version) MarshalJSON() ([]byte, error) {
+	return quoteJSON(v.String()), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v PEP440Version) MarshalText() ([]byte, error) {
+	return v.serialize(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *PEP440Version) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, v.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *PEP440Version) UnmarshalText(b []byte) error {
+	pv, err := NewPEP440Version(b)
+	if err != nil {
+		return err
+	}
+	*v = pv
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *PEP440Version) Scan(src interface{}) error {
+	return scanText(src, v.UnmarshalText, errInvalidPEP440Type)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (v PEP440Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// PEP440VersionPtrs represents an array with elements derived from PEP440Version.
+// Use it to sort them, in the order PEP 440 prescribes.
+type PEP440VersionPtrs []*PEP440Version
+
+// Len implements the sort.Interface.
+func (p PEP440VersionPtrs) Len() int {
+	return len(p)
+}
+
+// Swap implements the sort.Interface.
+func (p PEP440VersionPtrs) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements the sort.Interface.
+func (p PEP440VersionPtrs) Less(i, j int) bool {
+	if p[i] == nil {
+		return false
+	} else if p[j] == nil {
+		return true
+	}
+	return p[i].Less(p[j])
+}
+
+// Sort reorders the pointers so that the PEP440Versions appear in ascending order.
+// Any nil pointers go last.
+func (p PEP440VersionPtrs) Sort() {
+	sort.Stable(p)
+}
+
+// pep440Operators in the order they are tried, the longer before any shorter one.
+var pep440Operators = [...]string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"}
+
+// pep440Specifier is one clause of a PEP440SpecifierSet, such as "~=1.4.2" or "!=1.5.*".
+type pep440Specifier struct {
+	operator  string
+	version   PEP440Version
+	wildcard  bool   // For "==" and "!=" with a trailing ".*".
+	arbitrary string // The string after "===".
+}
+
+// PEP440SpecifierSet is a set of clauses such as "~=1.4.2, !=1.5.*, <2",
+// which a PEP440Version must all match.
+//
+// An empty PEP440SpecifierSet contains everything.
+type PEP440SpecifierSet struct {
+	specifiers []pep440Specifier
+}
+
+// NewPEP440SpecifierSet reads clauses, separated by commas, each of which is an operator
+// and a version: "~=" for a compatible release, "==" and "!=" optionally with a trailing ".*",
+// "<=", ">=", "<", ">", and "===" which compares strings.
+// Whitespace between them is ignored.
+func NewPEP440SpecifierSet(str []byte) (PEP440SpecifierSet, error) {
+	var set PEP440SpecifierSet
+	if len(bytes.TrimSpace(str)) == 0 {
+		return set, nil
+	}
+	var offset int
+	for _, clause := range bytes.Split(str, []byte(",")) {
+		s, err := newPEP440Specifier(clause)
+		if err != nil {
+			return PEP440SpecifierSet{}, atOffset(err, str, offset)
+		}
+		set.specifiers = append(set.specifiers, s)
+		offset += len(clause) + 1
+	}
+	return set, nil
+}
+
+// ParsePEP440SpecifierSet is NewPEP440SpecifierSet for strings.
+func ParsePEP440SpecifierSet(str string) (PEP440SpecifierSet, error) {
+	return NewPEP440SpecifierSet([]byte(str))
+}
+
+func newPEP440Specifier(str []byte) (pep440Specifier, error) {
+	var s pep440Specifier
+	idx := len(str) - len(bytes.TrimLeftFunc(str, unicode.IsSpace))
+	for _, operator := range pep440Operators {
+		if bytes.HasPrefix(str[idx:], []byte(operator)) {
+			s.operator = operator
+			break
+		}
+	}
+	if s.operator == "" {
+		return pep440Specifier{}, newParseError(errInvalidPEP440Specifier, str, idx, "one of ~=, ==, !=, <=, >=, <, >, or ===")
+	}
+	idx += len(s.operator)
+	idx += len(str[idx:]) - len(bytes.TrimLeftFunc(str[idx:], unicode.IsSpace))
+	end := len(bytes.TrimRightFunc(str, unicode.IsSpace))
+
+	if s.operator == "===" {
+		if idx >= end {
+			return pep440Specifier{}, newParseError(errInvalidPEP440Specifier, str, idx, "a string")
+		}
+		if n := bytes.IndexFunc(str[idx:end], unicode.IsSpace); n >= 0 {
+			return pep440Specifier{}, newParseError(errInvalidPEP440Specifier, str, idx+n, "a string without whitespace")
+		}
+		s.arbitrary = string(str[idx:end])
+		s.version, _ = NewPEP440Version(str[idx:end])
+		return s, nil
+	}
+
+	if bytes.HasSuffix(str[idx:end], []byte(".*")) {
+		if s.operator != "==" && s.operator != "!=" {
+			return pep440Specifier{}, newParseError(errInvalidPEP440Specifier, str, end-2, "no wildcard, except after == or !=")
+		}
+		s.wildcard = true
+		end -= 2
+	}
+	v, err := NewPEP440Version(str[idx:end])
+	if err != nil {
+		return pep440Specifier{}, atOffset(err, str, idx)
+	}
+	switch {
+	case s.wildcard && (v.preKind >= 0 || v.hasPost || v.hasDev || len(v.local) > 0):
+		return pep440Specifier{}, newParseError(errInvalidPEP440Specifier, str, end, "only a release before the wildcard")
+	case len(v.local) > 0 && s.operator != "==" && s.operator != "!=":
+		return pep440Specifier{}, newParseError(errInvalidPEP440Specifier, str, bytes.IndexByte(str, '+'), "no local version, except after == or !=")
+	case s.operator == "~=" && len(v.release) < 2:
+		return pep440Specifier{}, newParseError(errInvalidPEP440Specifier, str, end, "at least two release components after ~=")
+	}
+	s.version = v
+	return s, nil
+}
+
+// contains is true if v matches the clause, regardless of whether it is a pre-release.
+func (s pep440Specifier) contains(v PEP440Version) bool {
+	switch s.operator {
+	case "===":
+		return strings.EqualFold(v.String(), s.arbitrary)
+	case "==":
+		return s.equals(v)
+	case "!=":
+		return !s.equals(v)
+	case "~=":
+		prefix := pep440Specifier{version: s.version.base(), wildcard: true}
+		prefix.version.release = prefix.version.release[:len(prefix.version.release)-1]
+		return v.public().Compare(s.version) >= 0 && prefix.equals(v)
+	case "<=":
+		return v.public().Compare(s.version) <= 0
+	case ">=":
+		return v.public().Compare(s.version) >= 0
+	case "<":
+		// "<1.0" excludes 1.0a1, unless it's written as "<1.0rc1" or the like.
+		if v.Compare(s.version) >= 0 {
+			return false
+		}
+		return s.version.IsPreRelease() || !v.IsPreRelease() || v.base().Compare(s.version.base()) != 0
+	case ">":
+		// ">1.0" excludes 1.0.post1 and 1.0+local, unless it's written as ">1.0.post0" or the like.
+		if v.Compare(s.version) <= 0 {
+			return false
+		}
+		if !s.version.hasPost && v.hasPost && v.base().Compare(s.version.base()) == 0 {
+			return false
+		}
+		return len(v.local) == 0 || v.base().Compare(s.version.base()) != 0
+	}
+	return false
+}
+
+// equals implements "==", with or without a wildcard.
+func (s pep440Specifier) equals(v PEP440Version) bool {
+	if !s.wildcard {
+		if len(s.version.local) == 0 {
+			v = v.public()
+		}
+		return v.Compare(s.version) == 0
+	}
+
+	if v.epoch != s.version.epoch {
+		return false
+	}
+	for i, number := range s.version.release {
+		component := "0"
+		if i < len(v.release) {
+			component = v.release[i]
+		}
+		if compareDecimals(component, number) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsPreReleases is true if the clause names a pre-release as version.
+func (s pep440Specifier) allowsPreReleases() bool {
+	switch s.operator {
+	case "!=":
+		return false
+	case "===":
+		return s.version.release != nil && s.version.IsPreRelease()
+	}
+	return s.version.IsPreRelease()
+}
+
+// String returns the clause in its normalized notation.
+func (s pep440Specifier) String() string {
+	if s.operator == "===" {
+		return s.operator + s.arbitrary
+	}
+	if s.wildcard {
+		return s.operator + s.version.String() + ".*"
+	}
+	return s.operator + s.version.String()
+}
+
+// Contains returns true if the PEP440Version matches every clause.
+//
+// If in doubt use IsSatisfiedBy.
+func (set PEP440SpecifierSet) Contains(v PEP440Version) bool {
+	for i := range set.specifiers {
+		if !set.specifiers[i].contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSatisfiedBy works like Contains,
+// but rejects pre-releases unless any clause names one, such as ">=1.0b1".
+//
+// This is how PEP 440 wants installers to pick a version.
+func (set PEP440SpecifierSet) IsSatisfiedBy(v PEP440Version) bool {
+	if !set.Contains(v) {
+		return false
+	}
+	if !v.IsPreRelease() {
+		return true
+	}
+	for i := range set.specifiers {
+		if set.specifiers[i].allowsPreReleases() {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the PEP440SpecifierSet in its normalized notation, such as "~=1.4.2,!=1.5.*".
+func (set PEP440SpecifierSet) String() string {
+	clauses := make([]string, len(set.specifiers))
+	for i := range set.specifiers {
+		clauses[i] = set.specifiers[i].String()
+	}
+	return strings.Join(clauses, ",")
+}
@@ -0,0 +1,246 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParsePEP440(str string) PEP440Version {
+	v, err := ParsePEP440(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestNewPEP440Version(t *testing.T) {
+	Convey("NewPEP440Version reads, and normalizes…", t, FailureContinues, func() {
+		for str, normalized := range map[string]string{
+			"1.0":                   "1.0",
+			"2!1.0":                 "2!1.0",
+			"0!1.0":                 "1.0",
+			"v1.0":                  "1.0",
+			" 1.0\n":                "1.0",
+			"01.002.0":              "1.2.0",
+			"1.0a1":                 "1.0a1",
+			"1.0-ALPHA.1":           "1.0a1",
+			"1.0.beta_2":            "1.0b2",
+			"1.0c1":                 "1.0rc1",
+			"1.0preview1":           "1.0rc1",
+			"1.0pre":                "1.0rc0",
+			"1.0.post1":             "1.0.post1",
+			"1.0-1":                 "1.0.post1",
+			"1.0-r1":                "1.0.post1",
+			"1.0rev":                "1.0.post0",
+			"1.0.dev3":              "1.0.dev3",
+			"1.0dev":                "1.0.dev0",
+			"1.0a1-post2_DEV3":      "1.0a1.post2.dev3",
+			"1.0+ubuntu.1":          "1.0+ubuntu.1",
+			"1.0+Ubuntu-01_A":       "1.0+ubuntu.1.a",
+			"1!2.0rc1.post2.dev3+x": "1!2.0rc1.post2.dev3+x",
+		} {
+			Convey(str, func() {
+				v, err := ParsePEP440(str)
+				So(err, ShouldBeNil)
+				So(v.String(), ShouldEqual, normalized)
+			})
+		}
+	})
+
+	Convey("NewPEP440Version rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":           0,
+			"v":          1,
+			"1.0x":       3,
+			"1.0abc":     4,
+			"1.0+":       4,
+			"1.0+a..b":   6,
+			"1.0.dev1a1": 8,
+			"1.0 1":      3,
+			"1!":         2,
+			"1.0-":       3,
+		} {
+			Convey(str, func() {
+				_, err := ParsePEP440(str)
+				So(errors.Is(err, errInvalidPEP440Version), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+
+	Convey("Pre-releases are…", t, func() {
+		So(mustParsePEP440("1.0a1").IsPreRelease(), ShouldBeTrue)
+		So(mustParsePEP440("1.0.dev1").IsPreRelease(), ShouldBeTrue)
+		So(mustParsePEP440("1.0.post1.dev1").IsPreRelease(), ShouldBeTrue)
+		So(mustParsePEP440("1.0.post1").IsPreRelease(), ShouldBeFalse)
+		So(mustParsePEP440("1.0.post1").IsPostRelease(), ShouldBeTrue)
+		So(mustParsePEP440("1.0+dev1").IsPreRelease(), ShouldBeFalse)
+	})
+}
+
+func TestPEP440VersionOrder(t *testing.T) {
+	// The order used in the tests of PyPA's packaging.
+	ordered := []string{
+		"1.0.dev456", "1.0a1", "1.0a2.dev456", "1.0a12.dev456", "1.0a12",
+		"1.0b1.dev456", "1.0b2", "1.0b2.post345.dev456", "1.0b2.post345", "1.0b2-346",
+		"1.0c1.dev456", "1.0c1", "1.0rc2", "1.0c3", "1.0",
+		"1.0.post456.dev34", "1.0.post456", "1.1.dev1",
+		"1.2+123abc", "1.2+123abc456", "1.2+abc", "1.2+abc123", "1.2+abc123def",
+		"1.2+1234.abc", "1.2+123456", "1.2.r32+123456", "1.2.rev33+123456",
+		"1!1.0.dev456", "1!1.0a1", "1!1.0", "1!1.2.rev33+123456",
+	}
+
+	Convey("PEP440Versions are ordered as in PyPA's packaging", t, func() {
+		for i := 0; i+1 < len(ordered); i++ {
+			a, b := mustParsePEP440(ordered[i]), mustParsePEP440(ordered[i+1])
+			So(a.Compare(b), ShouldEqual, -1)
+			So(b.Compare(a), ShouldEqual, 1)
+		}
+		So(mustParsePEP440("1.0").Compare(mustParsePEP440("1.0.0.0")), ShouldEqual, 0)
+		So(mustParsePEP440("1.0+1").Compare(mustParsePEP440("1.0+01")), ShouldEqual, 0)
+
+		Convey("also by PEP440VersionPtrs, nil last", func() {
+			ptrs := PEP440VersionPtrs{nil}
+			for i := len(ordered) - 1; i >= 0; i-- {
+				v := mustParsePEP440(ordered[i])
+				ptrs = append(ptrs, &v)
+			}
+			ptrs.Sort()
+			for i := range ordered {
+				So(ptrs[i].Compare(mustParsePEP440(ordered[i])), ShouldEqual, 0)
+			}
+			So(ptrs[len(ordered)], ShouldBeNil)
+		})
+	})
+
+	Convey("PEP440Version is written normalized…", t, FailureContinues, func() {
+		Convey("to JSON, as pip freeze would", func() {
+			out, err := json.Marshal(map[string]PEP440Version{
+				"requests": mustParsePEP440("V2.31.0-1"),
+				"Django":   mustParsePEP440("5.0C1"),
+			})
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `{"Django":"5.0rc1","requests":"2.31.0.post1"}`)
+		})
+
+		Convey("after reading JSON with escaped whitespace around it", func() {
+			var v PEP440Version
+			So(json.Unmarshal([]byte(`"\t1.0-ALPHA.1\n"`), &v), ShouldBeNil)
+			So(v.String(), ShouldEqual, "1.0a1")
+		})
+
+		Convey("with the local version, which JSON null doesn't touch", func() {
+			v := mustParsePEP440("2!1.0rc1.post2.dev3+Ubuntu-1")
+			So(json.Unmarshal([]byte("null"), &v), ShouldBeNil)
+			text, err := v.MarshalText()
+			So(err, ShouldBeNil)
+			So(string(text), ShouldEqual, "2!1.0rc1.post2.dev3+ubuntu.1")
+		})
+	})
+
+	Convey("PEP440Version is scanned from databases", t, func() {
+		v := mustParsePEP440("1.0+ubuntu.1")
+		val, _ := v.Value()
+		var back PEP440Version
+		So(back.Scan(val), ShouldBeNil)
+		So(back, ShouldResemble, v)
+		So(back.Scan([]byte("1.0.POST.1")), ShouldBeNil)
+		So(back.String(), ShouldEqual, "1.0.post1")
+		So(errors.Is(back.Scan("1.0+"), errInvalidPEP440Version), ShouldBeTrue)
+		So(back.Scan(3.14), ShouldEqual, errInvalidPEP440Type)
+	})
+}
+
+func TestPEP440SpecifierSet(t *testing.T) {
+	Convey("PEP440SpecifierSet contains…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"":                    {"1.0": true, "1.0a1": true},
+			"==2":                 {"2.0": true, "2.0.0": true, "2.0+deadbeef": true, "2.0.1": false},
+			"==2.0+deadbeef":      {"2.0+deadbeef": true, "2.0": false, "2.0+deadbeef.0": false},
+			"==2.0.0+deadbeef.00": {"2.0+deadbeef.0": true},
+			"==2.*":               {"2.0.0": true, "2.1a1": true, "2": true, "3.0": false, "1!2.0": false},
+			"==2.0.*":             {"2.0.9": true, "2": true, "2.1": false},
+			"!=1.5.*":             {"1.5.0": false, "1.5.2.post1": false, "1.50": true, "1.4": true},
+			"!=2.0+deadbeef":      {"2.0.1": true, "2.0+deadbeef": false, "2.0": true},
+			"~=2.0":               {"2.1+local.version": true, "2.0": true, "3.0": false, "1.9": false},
+			"~=1.4.2":             {"1.4.2": true, "1.4.9": true, "1.5.0": false, "1.4.1": false},
+			"~=1.4.5a4":           {"1.4.5a4": true, "1.4.5": true, "1.4.6": true, "1.4.5a3": false, "1.5": false},
+			"<=2":                 {"2.0": true, "2.0+local": true, "2.0.post1": false},
+			">=2.0.0":             {"2.0": true, "1.9": false},
+			"<1.0":                {"0.9": true, "1.0a1": false, "1.0.dev1": false, "0.9a1": true, "1.0": false},
+			"<1.0rc1":             {"1.0a1": true, "1.0rc1": false},
+			">1.0":                {"1.1": true, "1.0.post1": false, "1.0+local": false, "1.0": false, "1.1.post1": true},
+			">1.0.post1":          {"1.0.post2": true, "1.0.post1": false},
+			"===1.0":              {"1.0": true, "1.0.0": false, "1.0+local": false},
+			"===Foo":              {"1.0": false},
+			" >= 1.0 , < 2.0 ":    {"1.5": true, "2.0": false, "0.9": false},
+		} {
+			s, err := ParsePEP440SpecifierSet(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(s.Contains(mustParsePEP440(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("IsSatisfiedBy excludes pre-releases…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"":             {"1.0": true, "1.0a1": false, "1.0.dev1": false, "1.0.post1": true},
+			">=1.0":        {"2.0a1": false, "2.0": true},
+			">=1.0b1":      {"1.0b2": true, "2.0a1": true, "2.0.dev1": true},
+			"!=1.0a1":      {"1.0a2": false},
+			"~=1.0rc1, <2": {"1.0rc2": true},
+			"==1.0.*":      {"1.0a1": false},
+			"===1.0a1":     {"1.0a1": true},
+		} {
+			s, err := ParsePEP440SpecifierSet(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(s.IsSatisfiedBy(mustParsePEP440(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("PEP440SpecifierSet is written normalized", t, func() {
+		s, err := ParsePEP440SpecifierSet(" ~= 1.4.2, != 1.5.* ,<2.0-ALPHA, ===foo ")
+		So(err, ShouldBeNil)
+		So(s.String(), ShouldEqual, "~=1.4.2,!=1.5.*,<2.0a0,===foo")
+	})
+
+	Convey("NewPEP440SpecifierSet rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"1.0":         0,
+			"=>1.0":       0,
+			">=1.0,":      6,
+			">=1.0, <x":   8,
+			">=1.*":       3,
+			"==1.0a1.*":   7,
+			"<=1.0+local": 5,
+			"~=1":         3,
+			"~=1.0.*":     5,
+			"=== foo bar": 7,
+			"===":         3,
+		} {
+			Convey(str, func() {
+				_, err := ParsePEP440SpecifierSet(str)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Input, ShouldEqual, str)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
@@ -0,0 +1,271 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// NewVersionWithIdentifiers works like NewVersion, but reads any pre-release
+// as dot-separated alphanumeric identifiers as SemVer 2.0 does, such as in
+// "1.0.0-x.7.z.92", "2.0.0-nightly.20240101" or "1.0.0-SNAPSHOT",
+// and any build metadata, such as in "1.0.0+sha.5114f85" or "1.0.0+20130313144700".
+//
+// Pre-releases are ordered by §11 SemVer: identifiers consisting of only digits
+// are compared numerically and below alphanumeric ones, which are compared in ASCII order,
+// and if all preceding identifiers are equal, fewer sort first.
+// Hence, unlike by NewVersion, "1.0.0-alpha.beta" is after "1.0.0-alpha.1",
+// and "1.0.0-1" or "1.0.0-p1" are pre-releases before "1.0.0".
+// Those of one of alpha, beta, pre and rc followed by only numbers, such as "rc.1",
+// are stored as NewVersion does, which orders them the same. Any other are kept verbatim.
+//
+// A release type without a hyphen, as in "1.0.0rc1", is read as NewVersion does.
+//
+// Build metadata other than "+buildNNN" is kept verbatim, too, and never compared.
+func NewVersionWithIdentifiers(str []byte) (Version, error) {
+	input := str
+	var metadata []byte
+	if end := bytes.IndexByte(str, '+'); end >= 0 {
+		str, metadata = str[:end], str[end+1:]
+		if bad := invalidIdentifierAt(metadata); bad >= 0 {
+			return Version{}, newParseError(errInvalidVersionString, input, end+1+bad, "build metadata of [0-9A-Za-z-]")
+		}
+	}
+
+	var ver Version
+	var err error
+	if start := bytes.IndexByte(str, '-'); start > 0 && isValidPrefix(str[:start]) {
+		if bad := invalidIdentifierAt(str[start+1:]); bad >= 0 {
+			return Version{}, newParseError(errInvalidVersionString, input, start+1+bad, "identifiers of [0-9A-Za-z-]")
+		}
+		ver, err = newVersionWithPreRelease(str)
+	} else {
+		ver, err = NewVersion(str)
+	}
+	if err != nil {
+		return Version{}, atOffset(err, input, 0)
+	}
+
+	if len(metadata) > 0 {
+		ver.setBuildMetadata(metadata)
+	}
+	return ver, nil
+}
+
+// newVocabularyVersion reads the fixed vocabulary as NewVersion does,
+// such as "1.0.0-p1" as a post-release and "1.0.0-rc10" after "1.0.0-rc9",
+// and any other pre-release or build metadata as NewVersionWithIdentifiers does.
+// The lowest pre-release "1.0.0-0", which NewVersion would read as "1.0.0", is an identifier, too.
+func newVocabularyVersion(str []byte) (Version, error) {
+	core, metadata := str, []byte(nil)
+	if end := bytes.IndexByte(str, '+'); end >= 0 {
+		core, metadata = str[:end], str[end+1:]
+	}
+	if bytes.HasSuffix(core, []byte("-0")) && isValidPrefix(core[:len(core)-2]) {
+		return NewVersionWithIdentifiers(str)
+	}
+	if ver, err := NewVersion(str); err == nil {
+		if len(metadata) > 0 {
+			ver.setBuildMetadata(metadata) // Keeps "+build007" as it is.
+		}
+		return ver, nil
+	}
+	ver, err := NewVersionWithIdentifiers(str)
+	if err != nil {
+		return ver, err
+	}
+	if fixed, err := NewVersion(core); err == nil {
+		fixed.build, fixed.buildMetadata = ver.build, ver.buildMetadata
+		return fixed, nil
+	}
+	return ver, nil
+}
+
+// setBuildMetadata stores the given build metadata, which has been validated,
+// as number if it's in the form "buildNNN" and gets written back the same, else verbatim.
+// Hence "build0" and "build007" are kept as they are.
+func (t *Version) setBuildMetadata(metadata []byte) {
+	if bytes.HasPrefix(metadata, buildsuffix[1:]) {
+		digits := metadata[len(buildsuffix)-1:]
+		if n, num := atoui(digits); n > 0 && n <= 9 && n == len(digits) && digits[0] != '0' {
+			t.build, t.buildMetadata = int32(num), ""
+			return
+		}
+	}
+	t.build, t.buildMetadata = 0, string(metadata)
+}
+
+// newVersionWithPreRelease reads a Version whose pre-release is of arbitrary identifiers,
+// which must be free of any build metadata.
+func newVersionWithPreRelease(str []byte) (Version, error) {
+	start := bytes.IndexByte(str, '-')
+	if start <= 0 || start+1 >= len(str) || !isValidPrefix(str[:start]) || !isValidIdentifiers(str[start+1:]) {
+		return Version{}, errInvalidVersionString
+	}
+
+	ver, err := NewVersion(str[:start])
+	if err != nil {
+		return ver, err
+	}
+	if compact, err := NewVersion(str); err == nil && compact.hasPlainPreRelease() &&
+		bytes.Equal(compact.identifiers(), str[start+1:]) {
+		return compact, nil
+	}
+	ver.version[idxReleaseType] = identified
+	ver.preRelease = string(str[start+1:])
+	return ver, nil
+}
+
+// hasPlainPreRelease is true if the pre-release is one of alpha, beta, pre and rc,
+// followed by nothing but numbers, which the fields then order as §11 SemVer does.
+func (t *Version) hasPlainPreRelease() bool {
+	if typ := t.version[idxReleaseType]; typ < alpha || typ > rc {
+		return false
+	}
+	for _, field := range t.version[idxSpecifierType:] {
+		if field != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidPrefix is true for a leading 'v' followed by at most four dot-separated numbers.
+func isValidPrefix(str []byte) bool {
+	if len(str) > 1 && str[0] == 'v' {
+		str = str[1:]
+	}
+	for _, ch := range str {
+		if !isNumeric(ch) && ch != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidIdentifiers is true for non-empty dot-separated identifiers of [0-9A-Za-z-].
+func isValidIdentifiers(str []byte) bool {
+	return invalidIdentifierAt(str) < 0
+}
+
+// invalidIdentifierAt returns the offset of the first character that breaks
+// the dot-separated identifiers, or of an empty one, or -1 if there is none.
+func invalidIdentifierAt(str []byte) int {
+	start := 0
+	for idx := 0; idx <= len(str); idx++ {
+		if idx == len(str) || str[idx] == '.' {
+			if idx == start {
+				return idx
+			}
+			start = idx + 1
+			continue
+		}
+		if ch := str[idx]; !isNumeric(ch) && !isSmallLetter(ch|0x20) && ch != '-' {
+			return idx
+		}
+	}
+	return -1
+}
+
+// identifiers renders the pre-release of t as dot-separated identifiers,
+// such as "rc.2" for a Version "1.0.0-rc2".
+func (t *Version) identifiers() []byte {
+	if t.version[idxReleaseType] == identified {
+		return []byte(t.preRelease)
+	}
+
+	target := make([]byte, 0, 16)
+	for _, typeIdx := range [...]int{idxReleaseType, idxSpecifierType} {
+		typ, numbers := t.version[typeIdx], t.version[typeIdx+1:typeIdx+5]
+		lastNonZero := -1
+		for i, n := range numbers {
+			if n != 0 {
+				lastNonZero = i
+			}
+		}
+		if typ == common && lastNonZero < 0 {
+			continue
+		}
+
+		if typ != common {
+			if len(target) > 0 {
+				target = append(target, '.')
+			}
+			target = append(target, releaseDesc[int(typ)]...)
+		}
+		for i := 0; i <= lastNonZero; i++ {
+			if len(target) > 0 {
+				target = append(target, '.')
+			}
+			target = strconv.AppendUint(target, uint64(numbers[i]), 10)
+		}
+	}
+	return target
+}
+
+// compareWithIdentifiers is Compare for Versions of which at least one
+// has pre-release identifiers beyond the fixed vocabulary.
+func compareWithIdentifiers(a, b *Version) int {
+	if d := signDelta(a.version, b.version, idxReleaseType); d != 0 {
+		return int(d)
+	}
+	if a.version[idxReleaseType] >= common || b.version[idxReleaseType] >= common {
+		return int(signDelta(a.version, b.version, len(a.version)))
+	}
+	return compareIdentifiers(a.identifiers(), b.identifiers())
+}
+
+// compareIdentifiers orders dot-separated pre-release identifiers by §11 SemVer.
+func compareIdentifiers(a, b []byte) int {
+	for len(a) > 0 && len(b) > 0 {
+		var x, y []byte
+		x, a = cutIdentifier(a)
+		y, b = cutIdentifier(b)
+
+		xNumeric, yNumeric := isNumericIdentifier(x), isNumericIdentifier(y)
+		switch {
+		case xNumeric && yNumeric:
+			x, y = bytes.TrimLeft(x, "0"), bytes.TrimLeft(y, "0")
+			if len(x) != len(y) {
+				return signum(len(x) - len(y))
+			}
+		case xNumeric:
+			return -1
+		case yNumeric:
+			return 1
+		}
+		if c := bytes.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return signum(len(a) - len(b))
+}
+
+func cutIdentifier(str []byte) (identifier, remainder []byte) {
+	if idx := bytes.IndexByte(str, '.'); idx >= 0 {
+		return str[:idx], str[idx+1:]
+	}
+	return str, nil
+}
+
+func isNumericIdentifier(identifier []byte) bool {
+	for _, ch := range identifier {
+		if !isNumeric(ch) {
+			return false
+		}
+	}
+	return true
+}
+
+func signum(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,199 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewVersionWithIdentifiers(t *testing.T) {
+	Convey("NewVersionWithIdentifiers works with…", t, FailureContinues, func() {
+		for _, str := range []string{
+			"1.0.0-x.7.z.92", "2.0.0-nightly.20240101", "1.0.0-SNAPSHOT",
+			"1.0.0-0.x", "1.0.0-x-y-z.--", "v1.2-gazilla+build5",
+		} {
+			Convey(str, func() {
+				v, err := NewVersionWithIdentifiers([]byte(str))
+				So(err, ShouldBeNil)
+				So(v.IsAPreRelease(), ShouldBeTrue)
+				So(v.preRelease, ShouldNotBeEmpty)
+			})
+		}
+
+		Convey("the fixed vocabulary in the notation of SemVer as NewVersion does", func() {
+			for _, str := range []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-rc.2", "1.0.0-beta.2.1"} {
+				expect := MustParse(str)
+				v, err := NewVersionWithIdentifiers([]byte(str))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, expect)
+			}
+		})
+
+		Convey("any other pre-release as ParseStrict does", func() {
+			release := MustParse("1.0.0")
+			for _, str := range []string{"1.0.0-alpha.beta", "1.0.0-rc2", "1.0.0-1", "1.0.0-0.3.7", "1.0.0-p1", "1.0.0-RC.1"} {
+				strict, err := ParseStrict(str)
+				So(err, ShouldBeNil)
+				v, err := NewVersionWithIdentifiers([]byte(str))
+				So(err, ShouldBeNil)
+				So(v.preRelease, ShouldEqual, str[len("1.0.0-"):])
+				So(v.String(), ShouldEqual, str)
+				So(Compare(&v, &strict), ShouldEqual, 0)
+				So(Compare(&v, &release), ShouldEqual, -1)
+			}
+		})
+	})
+
+	Convey("NewVersionWithIdentifiers rejects…", t, FailureContinues, func() {
+		for _, str := range []string{
+			"1.0.0-", "1.0.0-x..y", "1.0.0-x.y!", "1.0.0.0.0-x", "1.0b-x", "-x", "1.0.0-x+sha..5", "1.0.0+", "1.0.0+sha_5",
+		} {
+			Convey(str, func() {
+				_, err := NewVersionWithIdentifiers([]byte(str))
+				So(err, ShouldNotBeNil)
+			})
+		}
+	})
+}
+
+func TestIdentifiersOrder(t *testing.T) {
+	Convey("Pre-releases with identifiers are ordered by §11 SemVer", t, func() {
+		ordered := []string{
+			"1.0.0-0.x", "1.0.0-SNAPSHOT",
+			"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta",
+			"1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11",
+			"1.0.0-nightly.9", "1.0.0-nightly.20240101", "1.0.0-p1",
+			"1.0.0-rc.1", "1.0.0-rc1", "1.0.0-x.7.z.92",
+			"1.0.0", "1.0.1-SNAPSHOT",
+		}
+		for i := 0; i+1 < len(ordered); i++ {
+			a, _ := NewVersionWithIdentifiers([]byte(ordered[i]))
+			b, _ := NewVersionWithIdentifiers([]byte(ordered[i+1]))
+			So(Compare(&a, &b), ShouldEqual, -1)
+			So(Compare(&b, &a), ShouldEqual, 1)
+			So(a.Less(&b), ShouldBeTrue)
+			So(b.Less(&a), ShouldBeFalse)
+			So(a.limitedLess(b), ShouldBeTrue)
+		}
+
+		Convey("as in the example chain of the specification", func() {
+			chain := []string{
+				"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+				"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0",
+			}
+			for i := 0; i+1 < len(chain); i++ {
+				a, _ := NewVersionWithIdentifiers([]byte(chain[i]))
+				b, _ := NewVersionWithIdentifiers([]byte(chain[i+1]))
+				So(Compare(&a, &b), ShouldEqual, -1)
+				So(Compare(&b, &a), ShouldEqual, 1)
+			}
+		})
+
+		Convey("and equal ones are equal", func() {
+			a, _ := NewVersionWithIdentifiers([]byte("1.0.0-x.7"))
+			b, _ := NewVersionWithIdentifiers([]byte("1.0.0-x.07"))
+			So(Compare(&a, &b), ShouldEqual, 0)
+			So(a.LimitedEqual(b), ShouldBeTrue)
+		})
+
+		Convey("also when sorting large collections", func() {
+			collection := make([]Version, 0, 4*thresholdForResidualSort)
+			for len(collection) < cap(collection) {
+				for _, str := range ordered {
+					v, _ := NewVersionWithIdentifiers([]byte(str))
+					collection = append(collection, v)
+				}
+			}
+			ptrs := make(VersionPtrs, len(collection))
+			for i := len(collection) - 1; i >= 0; i-- {
+				ptrs[len(collection)-1-i] = &collection[i]
+			}
+			ptrs.Sort()
+			for i := 0; i+1 < len(ptrs); i++ {
+				So(Compare(ptrs[i], ptrs[i+1]), ShouldBeLessThanOrEqualTo, 0)
+			}
+		})
+	})
+}
+
+func TestIdentifiersSerialization(t *testing.T) {
+	Convey("Versions with pre-release identifiers round-trip", t, func() {
+		for _, str := range []string{"1.0.0-x.7.z.92", "2.0.0-nightly.20240101+build3"} {
+			v, _ := NewVersionWithIdentifiers([]byte(str))
+			So(v.String(), ShouldEqual, str)
+
+			out, err := json.Marshal(v)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `"`+string(v.Bytes())+`"`)
+
+			var back Version
+			So(json.Unmarshal(out, &back), ShouldBeNil)
+			So(back, ShouldResemble, v)
+		}
+	})
+}
+
+func TestBuildMetadata(t *testing.T) {
+	Convey("NewVersionWithIdentifiers keeps build metadata…", t, FailureContinues, func() {
+		for str, build := range map[string]string{
+			"1.0.0+sha.5114f85":          "sha.5114f85",
+			"1.0.0+20130313144700":       "20130313144700",
+			"1.0.0-rc.a+exp.sha.5114f85": "exp.sha.5114f85",
+			"1.0.0-x.7+build.11.e0f985a": "build.11.e0f985a",
+			"1.3.8+build2014":            "build2014",
+			"1.3.8+build0":               "build0",
+			"1.3.8+build007":             "build007",
+			"1.3.8+build1234567890":      "build1234567890",
+			"1.3.8":                      "",
+		} {
+			Convey(str, func() {
+				v, err := NewVersionWithIdentifiers([]byte(str))
+				So(err, ShouldBeNil)
+				So(v.Build(), ShouldEqual, build)
+				So(string(v.Bytes()), ShouldEndWith, build)
+
+				Convey("that round-trips", func() {
+					out, err := json.Marshal(v)
+					So(err, ShouldBeNil)
+					var back Version
+					So(json.Unmarshal(out, &back), ShouldBeNil)
+					So(back, ShouldResemble, v)
+					So(back.String(), ShouldEqual, v.String())
+				})
+			})
+		}
+
+		Convey("but reads +buildNNN as NewVersion does", func() {
+			v, _ := NewVersionWithIdentifiers([]byte("1.3.8+build2014"))
+			So(v, ShouldResemble, MustParse("1.3.8+build2014"))
+		})
+	})
+
+	Convey("NewVersion reads only +buildNNN as build metadata", t, func() {
+		_, err := NewVersion([]byte("1.0.0+sha.5114f85"))
+		So(errors.Is(err, errInvalidBuildSuffix), ShouldBeTrue)
+		So(MustParse("1.3.8+build7").Build(), ShouldEqual, "build7")
+	})
+
+	Convey("NewRange reads any as NewVersionWithIdentifiers does", t, func() {
+		r, err := NewRange([]byte(">=1.0.0+sha.5114f85"))
+		So(err, ShouldBeNil)
+		So(r.GetLowerBoundary().Build(), ShouldEqual, "sha.5114f85")
+	})
+
+	Convey("Build metadata is ignored in Compare", t, func() {
+		a, _ := NewVersionWithIdentifiers([]byte("1.0.0+sha.5114f85"))
+		b, _ := NewVersionWithIdentifiers([]byte("1.0.0+20130313144700"))
+		c := MustParse("1.0.0")
+		So(Compare(&a, &b), ShouldEqual, 0)
+		So(Compare(&a, &c), ShouldEqual, 0)
+		So(a.Less(&b) || b.Less(&a), ShouldBeFalse)
+		So(a.LimitedEqual(c), ShouldBeTrue)
+	})
+}
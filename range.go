@@ -6,6 +6,8 @@ package semver
 
 import (
 	"bytes"
+	"sort"
+	"unicode"
 )
 
 // Range is a subset of the universe of Versions: It can have a lower and upper boundary.
@@ -19,19 +21,44 @@ type Range struct {
 	equalsUpper bool
 }
 
+var hyphenSeparator = []byte(" - ")
+
 // NewRange translates into a Range.
+//
+// Both boundaries can be given in a hyphen notation, "1.2.3 - 2.3.4",
+// which includes them. A partial upper boundary such as in "1.2.3 - 2.3"
+// includes any Version with that prefix, and therefore is read as "<2.4.0".
+// A side that is "*" or "x" leaves that boundary open, as in "1.2.3 - *".
+//
+// A partial Version after an operator is read as npm does: "=1.2" is "1.2.x",
+// ">1.2" is ">=1.3.0", "<=1.2" is "<1.3.0", whereas ">=1.2" is ">=1.2.0" and "<1.2" is "<1.2.0".
+//
+// Versions are read as by NewVersion, and any pre-release or build metadata beyond
+// its vocabulary, such as in ">=1.0.0-SNAPSHOT", as by NewVersionWithIdentifiers.
 func NewRange(str []byte) (Range, error) {
-	if len(str) == 0 || (len(str) == 1 && (str[0] == '*' || str[0] == 'x')) {
+	return newRange(str, newVocabularyVersion)
+}
+
+// newRange is NewRange with the given function to read the Versions of boundaries.
+func newRange(str []byte, parse func([]byte) (Version, error)) (Range, error) {
+	input := str
+	if len(str) == 0 || isWildcard(str) {
 		// An empty Range contains everything.
 		return Range{}, nil
 	}
+	if idx := bytes.IndexByte(str, '|'); idx >= 0 {
+		return Range{}, newParseError(errUnionInRange, str, idx, "a single Range")
+	}
+	if idx := bytes.Index(str, hyphenSeparator); idx > 0 {
+		return newHyphenRange(str, idx, parse)
+	}
 	isNaturalRange := true
 	if bytes.HasSuffix(str, []byte(".x")) || bytes.HasSuffix(str, []byte(".*")) {
 		str = bytes.TrimRight(str, ".x*")
 		isNaturalRange = false
 	}
 	if str[0] == '^' || str[0] == '~' {
-		return newRangeByShortcut(str)
+		return newRangeByShortcut(str, parse)
 	}
 
 	var upperBound, lowerBound bool = true, true
@@ -56,68 +83,154 @@ func NewRange(str []byte) (Range, error) {
 	}
 
 	isNaturalRange = isNaturalRange && leftEnd != rightStart && (len(str)-rightStart) > 0
-	if !isNaturalRange {
+	// A partial Version without any operator, or with "=", is a shortcut; "<0.5" is a bound.
+	if !isNaturalRange && lowerBound && upperBound {
 		leftDotCount := bytes.Count(str[:leftEnd], []byte{'.'})
-		switch leftDotCount {
-		case 1:
-			return newRangeByShortcut(append([]byte{'~'}, str...))
-		case 0:
-			return newRangeByShortcut(append([]byte{'^'}, str...))
+		if leftDotCount <= 1 {
+			operator := byte('~')
+			if leftDotCount == 0 {
+				operator = '^'
+			}
+			discount := -1 // For the prepended operator.
+			if str[0] == '=' {
+				str, discount = str[1:], 0
+			}
+			vr, err := newRangeByShortcut(append([]byte{operator}, str...), parse)
+			return vr, atOffset(err, input, discount)
 		}
 	}
 	vr := Range{}
 	if leftEnd == rightStart {
-		err := vr.setBound(str, lowerBound, upperBound)
-		return vr, err
+		err := vr.setBound(str, lowerBound, upperBound, parse)
+		return vr, atOffset(err, input, 0)
 	}
 
-	if err := vr.setBound(str[:leftEnd], true, false); err != nil {
-		return vr, err
+	if err := vr.setBound(str[:leftEnd], true, false, parse); err != nil {
+		return vr, atOffset(err, input, 0)
 	}
-	if err := vr.setBound(str[rightStart:], false, true); err != nil {
-		return vr, err
+	if err := vr.setBound(str[rightStart:], false, true, parse); err != nil {
+		return vr, atOffset(err, input, rightStart)
 	}
 
 	return vr, nil
 }
 
-func (r *Range) setBound(str []byte, isLower, isUpper bool) error {
+func (r *Range) setBound(str []byte, isLower, isUpper bool, parse func([]byte) (Version, error)) error {
 	var versionStartIdx int
 	for ; versionStartIdx < len(str); versionStartIdx++ {
 		if isNumeric(str[versionStartIdx]) {
 			goto startFound
 		}
 	}
-	return errInvalidVersionString
+	return newParseError(errInvalidVersionString, str, len(str)-len(bytes.TrimLeft(str, "<>=v")), "a Version")
 
 startFound:
-	var err error
-	equalOk := versionStartIdx == 0 || bytes.IndexByte(str[:versionStartIdx], '=') > 0
+	v, err := parse(str[versionStartIdx:])
+	if err != nil {
+		return atOffset(err, str, versionStartIdx)
+	}
+	equalOk := versionStartIdx == 0 || bytes.IndexByte(str[:versionStartIdx], '=') >= 0 ||
+		(isLower && isUpper) // Even with a prefix such as 'v' an exact Version includes itself.
 	if isUpper {
-		r.equalsUpper, r.hasUpper = equalOk, true
-		err = r.upper.unmarshalText(str[versionStartIdx:])
+		r.upper, r.equalsUpper, r.hasUpper = v, equalOk, true
 	}
 	if isLower {
-		r.equalsLower, r.hasLower = equalOk, true
-		if isUpper {
-			r.lower = r.upper
-		} else {
-			err = r.lower.unmarshalText(str[versionStartIdx:])
+		r.lower, r.equalsLower, r.hasLower = v, equalOk, true
+	}
+
+	// A partial Version stands for all with that prefix, hence "<=1.2" is "<1.3.0" and ">1.2" is ">=1.3.0".
+	if columns := partialColumns(str[versionStartIdx:]); columns > 0 && isLower != isUpper {
+		switch {
+		case isUpper && r.equalsUpper:
+			r.upper.version[columns-1]++
+			r.equalsUpper = false
+		case isLower && !r.equalsLower:
+			r.lower.version[columns-1]++
+			r.equalsLower = true
+		}
+	}
+	return nil
+}
+
+// partialColumns returns how many columns a Version of less than three has,
+// or 0 for any other, such as one with a release type.
+func partialColumns(str []byte) int {
+	columns := 1
+	for _, ch := range bytes.TrimPrefix(str, []byte{'v'}) {
+		if ch == '.' {
+			columns++
+			continue
+		}
+		if !isNumeric(ch) {
+			return 0
+		}
+	}
+	if columns >= 3 {
+		return 0
+	}
+	return columns
+}
+
+// atOffset translates a ParseError about a part of str, which starts at offset, into one about str.
+func atOffset(err error, str []byte, offset int) error {
+	e, ok := err.(*ParseError)
+	if !ok {
+		return err
+	}
+	offset += e.Offset
+	if offset < 0 { // Within something prepended that isn't part of str.
+		offset = 0
+	}
+	return newParseError(e.Err, str, offset, e.Expected)
+}
+
+// trimWildcards strips any tailing ".x" and ".*" from a Version in its string representation.
+func trimWildcards(str []byte) []byte {
+	for bytes.HasSuffix(str, []byte(".x")) || bytes.HasSuffix(str, []byte(".*")) {
+		str = str[:len(str)-2]
+	}
+	return str
+}
+
+// isWildcard is true for a lone "*" or "x", which stands for any Version.
+func isWildcard(str []byte) bool {
+	return len(str) == 1 && (str[0] == '*' || str[0] == 'x')
+}
+
+// newHyphenRange covers Ranges written as "A - B", with inclusive boundaries.
+//
+// An upper boundary with less than three columns becomes an exclusive one,
+// with its last given column incremented. Either side can be "*" or "x",
+// which leaves that boundary open as npm does: "* - 2.0" is "<2.1.0".
+func newHyphenRange(str []byte, separatorIdx int, parse func([]byte) (Version, error)) (Range, error) {
+	left, right := str[:separatorIdx], str[separatorIdx+len(hyphenSeparator):]
+	leftStart := len(left) - len(bytes.TrimLeftFunc(left, unicode.IsSpace))
+	rightStart := len(str) - len(bytes.TrimLeftFunc(right, unicode.IsSpace))
+	left, right = trimWildcards(bytes.TrimSpace(left)), trimWildcards(bytes.TrimSpace(right))
+	vr := Range{}
+	if !isWildcard(left) {
+		if err := vr.setBound(left, true, false, parse); err != nil {
+			return vr, atOffset(err, str, leftStart)
 		}
 	}
-	return err
+	if isWildcard(right) {
+		return vr, nil
+	}
+	err := vr.setBound(right, false, true, parse)
+	return vr, atOffset(err, str, rightStart)
 }
 
 // newRangeByShortcut covers the special case of Ranges whose boundaries
 // are declared using prefixes.
-func newRangeByShortcut(str []byte) (Range, error) {
+func newRangeByShortcut(str []byte, parse func([]byte) (Version, error)) (Range, error) {
 	t := bytes.TrimLeft(str, "~^")
-	num, err := NewVersion(t)
+	num, err := parse(t)
 	if err != nil {
-		return Range{}, err
+		return Range{}, atOffset(err, str, len(str)-len(t))
 	}
 	if bytes.HasPrefix(t, []byte("0.0.")) {
-		return NewRange(t)
+		r, err := newRange(t, parse)
+		return r, atOffset(err, str, len(str)-len(t))
 	}
 
 	r := Range{lower: num, hasLower: true, equalsLower: true, hasUpper: true, upper: Version{}}
@@ -154,9 +267,13 @@ func (r Range) GetUpperBoundary() *Version {
 
 // Contains returns true if a Version is inside this Range.
 //
+// An exclusive upper boundary such as "<1.2.0" excludes the pre-releases of its Version as well,
+// unless the lower boundary is one of them, as in ">=1.2.0-0 <1.2.0".
+//
 // If in doubt use IsSatisfiedBy.
 func (r Range) Contains(v Version) bool {
-	if r.upper == r.lower {
+	// Unbounded, both Versions are zero, yet that's no exact Version; nor is ">1.2.3 <1.2.3".
+	if r.hasLower && r.hasUpper && r.equalsLower && r.equalsUpper && r.upper == r.lower {
 		return r.lower.LimitedEqual(v)
 	}
 
@@ -207,26 +324,112 @@ func (r Range) satisfiesUpperBound(v Version) bool {
 		return true
 	}
 
-	if !r.equalsUpper && r.upper.version[idxReleaseType] == common {
+	if !r.equalsUpper && r.upper.version[idxReleaseType] == common && !r.admitsPreReleasesOfUpper() {
 		equal = r.upper.sharesPrefixWith(v)
 	}
 
 	return v.limitedLess(r.upper) && !equal
 }
 
+// admitsPreReleasesOfUpper is true if the lower boundary is a pre-release of the upper one.
+func (r Range) admitsPreReleasesOfUpper() bool {
+	return r.hasLower && r.hasUpper && r.lower.IsAPreRelease() && r.lower.sharesPrefixWith(r.upper)
+}
+
 // Satisfies is a convenience function for former NodeJS developers,
 // and works on two strings.
 //
+// The Range can be a union of several, separated by "||".
 // Please see Range's IsSatisfiedBy for details.
 func Satisfies(aVersion, aRange string) (bool, error) {
-	v, err := NewVersion([]byte(aVersion))
+	v, err := newVocabularyVersion([]byte(aVersion))
 	if err != nil {
 		return false, err
 	}
-	r, err := NewRange([]byte(aRange))
+	r, err := NewRangeSet([]byte(aRange))
 	if err != nil {
 		return false, err
 	}
 
 	return r.IsSatisfiedBy(v), nil
 }
+
+// MaxSatisfying returns the greatest of the Versions that satisfies this Range
+// by IsSatisfiedBy, or nil if none does.
+//
+// If the Versions are in ascending order, such as VersionPtrs.Sort leaves them,
+// only those between the boundaries are looked at, which are found by binary search.
+func (r Range) MaxSatisfying(versions VersionPtrs) *Version {
+	if lo, hi, sorted := r.window(versions); sorted {
+		for i := hi - 1; i >= lo; i-- {
+			if r.IsSatisfiedBy(*versions[i]) {
+				return versions[i]
+			}
+		}
+		return nil
+	}
+
+	var max *Version
+	for _, v := range versions {
+		if v != nil && (max == nil || max.Less(v)) && r.IsSatisfiedBy(*v) {
+			max = v
+		}
+	}
+	return max
+}
+
+// MinSatisfying returns the lowest of the Versions that satisfies this Range
+// by IsSatisfiedBy, or nil if none does.
+//
+// Like MaxSatisfying this exploits Versions in ascending order.
+func (r Range) MinSatisfying(versions VersionPtrs) *Version {
+	if lo, hi, sorted := r.window(versions); sorted {
+		for i := lo; i < hi; i++ {
+			if r.IsSatisfiedBy(*versions[i]) {
+				return versions[i]
+			}
+		}
+		return nil
+	}
+
+	var min *Version
+	for _, v := range versions {
+		if v != nil && (min == nil || v.Less(min)) && r.IsSatisfiedBy(*v) {
+			min = v
+		}
+	}
+	return min
+}
+
+// Filter returns those of the Versions that satisfy this Range by IsSatisfiedBy,
+// in the order they've been given. Nil pointers are dropped.
+//
+// Like MaxSatisfying this exploits Versions in ascending order.
+func (r Range) Filter(versions VersionPtrs) VersionPtrs {
+	lo, hi := 0, len(versions)
+	if l, h, sorted := r.window(versions); sorted {
+		lo, hi = l, h
+	}
+	var filtered VersionPtrs
+	for _, v := range versions[lo:hi] {
+		if v != nil && r.IsSatisfiedBy(*v) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// window returns the indices [lo, hi) of the Versions that are within the boundaries,
+// if the Versions are in ascending order. Nil pointers are considered past any boundary.
+func (r Range) window(versions VersionPtrs) (lo, hi int, sorted bool) {
+	if !sort.IsSorted(versions) {
+		return 0, 0, false
+	}
+	lo = sort.Search(len(versions), func(i int) bool {
+		return versions[i] == nil || r.satisfiesLowerBound(*versions[i])
+	})
+	hi = lo + sort.Search(len(versions)-lo, func(i int) bool {
+		return versions[lo+i] == nil || !r.satisfiesUpperBound(*versions[lo+i])
+	})
+	return lo, hi, true
+}
@@ -5,6 +5,8 @@
 package semver
 
 import (
+	"errors"
+	"sort"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -148,6 +150,70 @@ func TestRangeConstruction(t *testing.T) {
 		})
 	})
 
+	Convey("Hyphen Ranges with partial Versions…", t, func() {
+		v240 := MustParse("2.4.0")
+		v300 := MustParse("3.0.0")
+
+		Convey("1.2 - 2.3.4 equals: >=1.2.0 <=2.3.4", func() {
+			verRange, err := NewRange([]byte("1.2 - 2.3.4"))
+			So(err, ShouldBeNil)
+			So(verRange, hasLowerBound, v120)
+			So(verRange, hasUpperBound, MustParse("2.3.4"))
+		})
+
+		Convey("1.2.3 - 2.3 equals: >=1.2.3 <2.4.0", func() {
+			verRange, err := NewRange([]byte("1.2.3 - 2.3"))
+			So(err, ShouldBeNil)
+			So(verRange, hasLowerBound, v123)
+			So(verRange, isRightClosedBy, v240)
+			So(verRange, shouldContain, "2.3.99")
+			So(verRange, shouldNotContain, "2.4.0")
+		})
+
+		Convey("1.2.3 - 2 equals: >=1.2.3 <3.0.0", func() {
+			verRange, err := NewRange([]byte("1.2.3 - 2"))
+			So(err, ShouldBeNil)
+			So(verRange, hasLowerBound, v123)
+			So(verRange, isRightClosedBy, v300)
+		})
+
+		Convey("1.x - 2.x equals: >=1.0.0 <3.0.0", func() {
+			verRange, err := NewRange([]byte("1.x - 2.x"))
+			So(err, ShouldBeNil)
+			So(verRange, hasLowerBound, v100)
+			So(verRange, isRightClosedBy, v300)
+		})
+
+		Convey("* - 2.0 equals: <2.1.0", func() {
+			verRange, err := NewRange([]byte("* - 2.0"))
+			So(err, ShouldBeNil)
+			So(verRange.GetLowerBoundary(), ShouldBeNil)
+			So(verRange, isRightClosedBy, MustParse("2.1.0"))
+			So(verRange, shouldContain, "0.0.0", "2.0.9")
+		})
+
+		Convey("1.2 - x equals: >=1.2.0", func() {
+			verRange, err := NewRange([]byte("1.2 - x"))
+			So(err, ShouldBeNil)
+			So(verRange, hasLowerBound, v120)
+			So(verRange.GetUpperBoundary(), ShouldBeNil)
+			So(verRange, shouldContain, "1.2.0", "99.0.0")
+			So(verRange, shouldNotContain, "1.1.9")
+		})
+
+		Convey("* - * contains everything", func() {
+			verRange, err := NewRange([]byte("* - *"))
+			So(err, ShouldBeNil)
+			So(verRange, ShouldResemble, Range{})
+		})
+
+		Convey("1.2.3 - 2.0.0-rc1 keeps its pre-release boundary", func() {
+			verRange, err := NewRange([]byte("1.2.3 - 2.0.0-rc1"))
+			So(err, ShouldBeNil)
+			So(verRange, hasUpperBound, MustParse("2.0.0-rc1"))
+		})
+	})
+
 	Convey("Range ~1.2.3 equals: >=1.2.3 <1.3.0", t, func() {
 		verRange, err := NewRange([]byte("~1.2.3"))
 		So(err, ShouldBeNil)
@@ -374,7 +440,7 @@ func TestRangeConstruction(t *testing.T) {
 	Convey("Reject invalid ranges such as", t, func() {
 		for _, s := range []string{
 			" - 1.0", "- 1.0",
-			"1 - X", "X - 1",
+			"1 - X", "X - 1", "1 - **",
 			"1 - 5.6.7.8.9", "1.2.3.4.5 - 1",
 		} {
 			Convey(s, func() {
@@ -566,6 +632,104 @@ func TestSingleBound(t *testing.T) {
 
 }
 
+// Members of a RangeSet, such as ">=3" in "^1.2 || >=3", are often one-sided and partial.
+func TestOneSidedRange(t *testing.T) {
+	Convey("Partial Versions after an operator are bounds, which npm completes…", t, FailureContinues, func() {
+		upper, err := NewRange([]byte("<0.5"))
+		So(err, ShouldBeNil)
+		So(upper.GetLowerBoundary(), ShouldBeNil)
+		So(upper, isRightClosedBy, MustParse("0.5.0"))
+
+		lower, err := NewRange([]byte(">=1.2"))
+		So(err, ShouldBeNil)
+		So(lower.GetUpperBoundary(), ShouldBeNil)
+		So(lower, hasLowerBound, MustParse("1.2.0"))
+
+		for str, versions := range map[string][2][]interface{}{
+			">1.2":     {{"1.3.0", "3.0.0"}, {"1.2.9", "1.2.0", "1.3.0-rc1"}},
+			">1":       {{"2.0.0"}, {"1.9.9"}},
+			"<=2":      {{"2.9.9", "2.0.0", "0.1.0"}, {"3.0.0", "3.0.0-rc1"}},
+			"<1.2":     {{"1.1.9"}, {"1.2.0", "1.2.0-rc1"}},
+			"=1.2":     {{"1.2.0", "1.2.9"}, {"1.3.0", "1.1.0"}},
+			">1.2 <=2": {{"1.3.0", "2.5.0"}, {"1.2.5", "3.0.0"}},
+		} {
+			Convey(str, func() {
+				r, err := NewRange([]byte(str))
+				So(err, ShouldBeNil)
+				So(r, shouldContain, versions[0]...)
+				So(r, shouldNotContain, versions[1]...)
+			})
+		}
+	})
+
+	Convey("Partial Versions without an operator still are shortcuts…", t, FailureContinues, func() {
+		for str, versions := range map[string][2][]interface{}{
+			"1.2":   {{"1.2.0", "1.2.9"}, {"1.3.0", "1.1.9"}},
+			"1":     {{"1.0.0", "1.9.0"}, {"2.0.0", "0.9.0"}},
+			"1.2.x": {{"1.2.0", "1.2.9"}, {"1.3.0"}},
+		} {
+			Convey(str, func() {
+				r, err := NewRange([]byte(str))
+				So(err, ShouldBeNil)
+				So(r, shouldContain, versions[0]...)
+				So(r, shouldNotContain, versions[1]...)
+			})
+		}
+	})
+
+	Convey("Ranges without an upper bound contain more than their lower one…", t, FailureContinues, func() {
+		for _, str := range []string{"", "*", "x", ">=0.0.0"} {
+			Convey("'"+str+"'", func() {
+				r, err := NewRange([]byte(str))
+				So(err, ShouldBeNil)
+				So(r, shouldContain, "0.0.0", "1.2.3", "1.2.3-p1")
+			})
+		}
+	})
+
+	Convey("NewRange rejects unions, which NewRangeSet reads", t, func() {
+		_, err := NewRange([]byte("^1.2 || >=3"))
+		So(errors.Is(err, errUnionInRange), ShouldBeTrue)
+		var e *ParseError
+		So(errors.As(err, &e), ShouldBeTrue)
+		So(e.Offset, ShouldEqual, 5)
+
+		_, err = NewRangeSet([]byte("^1.2 || >=3"))
+		So(err, ShouldBeNil)
+	})
+}
+
+// Since Ranges have been intersected, their bounds tell whether they include themselves.
+func TestRangeOfOneVersion(t *testing.T) {
+	Convey("A single Version includes itself, as it always did…", t, FailureContinues, func() {
+		for _, str := range []string{"1.2.3", "v1.2.3", "=1.2.3", "=v1.2.3", ">=1.2.3 <=1.2.3"} {
+			Convey(str, func() {
+				r, err := NewRange([]byte(str))
+				So(err, ShouldBeNil)
+				So(r, shouldContain, "1.2.3")
+				So(r, shouldNotContain, "1.2.2", "1.2.4")
+				So(r.IsEmpty(), ShouldBeFalse)
+
+				Convey("now with inclusive bounds", func() {
+					So(r, hasLowerBound, MustParse("1.2.3"))
+					So(r, hasUpperBound, MustParse("1.2.3"))
+				})
+			})
+		}
+	})
+
+	Convey("Equal but exclusive bounds no longer contain that Version…", t, FailureContinues, func() {
+		for _, str := range []string{">1.2.3 <1.2.3", ">=1.2.3 <1.2.3", ">1.2.3 <=1.2.3"} {
+			Convey(str, func() {
+				r, err := NewRange([]byte(str))
+				So(err, ShouldBeNil)
+				So(r, shouldNotContain, "1.2.3", "1.2.2", "1.2.4")
+				So(r.IsEmpty(), ShouldBeTrue)
+			})
+		}
+	})
+}
+
 func TestSatisfies(t *testing.T) {
 
 	Convey("Convenience function 'Satisfies'", t, func() {
@@ -577,6 +741,16 @@ func TestSatisfies(t *testing.T) {
 			So(t, ShouldBeTrue)
 		})
 
+		Convey("works with pre-release identifiers", func() {
+			t, err := Satisfies("1.0.0-SNAPSHOT", ">=1.0.0-SNAPSHOT <2.0.0")
+			So(err, ShouldBeNil)
+			So(t, ShouldBeTrue)
+			t, _ = Satisfies("1.0.0-x.7", "^1.0.0-SNAPSHOT")
+			So(t, ShouldBeTrue)
+			t, _ = Satisfies("1.2.0-alpha", ">=1.2.0-0 <1.3.0")
+			So(t, ShouldBeTrue)
+		})
+
 		Convey("yields an error on invalid Version", func() {
 			t, err := Satisfies("1.2.3.4.5.6", "^1.2.2")
 			So(t, ShouldBeFalse)
@@ -628,6 +802,143 @@ func TestSatisfies(t *testing.T) {
 	})
 }
 
+func TestRangeParseError(t *testing.T) {
+	Convey("NewRange reports the offset into the whole Range with…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			">=1.2.3 <2.0.0-gamma!": 20,
+			">=1.2.3,<2.0.0-gamma!": 20,
+			"~1.2.3-gamma!":         12,
+			"1.2-gamma!":            9,
+			"1.2.3 - 2.0-gamma!":    17,
+			"1.2.3  -  2.0-gamma!":  19,
+			">=":                    2,
+			">=1 || <0.5":           4,
+		} {
+			Convey(str, func() {
+				_, err := NewRange([]byte(str))
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Input, ShouldEqual, str)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+
+	Convey("NewRange names the offending character of the whole Range", t, FailureContinues, func() {
+		for str, char := range map[string]rune{
+			">= 1.2.3":              ' ',
+			">=1.2.3 <2.0.0-gamma!": '!',
+			"^1.2.3-gamma!":         '!',
+			">=1.x.y":               'x',
+		} {
+			Convey(str, func() {
+				_, err := NewRange([]byte(str))
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Char, ShouldEqual, char)
+				So(string(e.Input[e.Offset]), ShouldEqual, string(char))
+			})
+		}
+
+		_, err := NewRange([]byte(">= 1.2.3"))
+		So(err.Error(), ShouldEqual, `Given string does not resemble a Version: unexpected ' ' at offset 2 of ">= 1.2.3", expected a Version`)
+	})
+
+	Convey("NewRangeSet reports the offset into the whole RangeSet", t, func() {
+		str := "^1.2 ||  >=3.1 <3.5-gam!ma"
+		_, err := NewRangeSet([]byte(str))
+		var e *ParseError
+		So(errors.As(err, &e), ShouldBeTrue)
+		So(e.Input, ShouldEqual, str)
+		So(e.Offset, ShouldEqual, 23)
+		So(e.Char, ShouldEqual, '!')
+		So(errors.Is(err, errInvalidVersionString), ShouldBeTrue)
+	})
+}
+
+func TestRangeSatisfying(t *testing.T) {
+	ordered := []string{
+		"0.9.0", "1.0.0-rc1", "1.0.0", "1.2.0", "1.2.3-beta", "1.2.3", "1.2.3-p1", "1.3.0", "2.0.0-alpha", "2.0.0", "2.1.0",
+	}
+	sorted := make(VersionPtrs, 0, len(ordered)+1)
+	for _, str := range ordered {
+		v := MustParse(str)
+		sorted = append(sorted, &v)
+	}
+	sorted = append(sorted, nil)
+	shuffled := VersionPtrs{sorted[5], sorted[11], sorted[0], sorted[9], sorted[2], sorted[7], sorted[4], sorted[10],
+		sorted[1], sorted[3], sorted[8], sorted[6]}
+
+	asStrings := func(p VersionPtrs) []string {
+		s := make([]string, len(p))
+		for i := range p {
+			s[i] = p[i].String()
+		}
+		return s
+	}
+
+	Convey("MaxSatisfying, MinSatisfying and Filter pick as IsSatisfiedBy does…", t, FailureContinues, func() {
+		So(sort.IsSorted(sorted), ShouldBeTrue)
+		So(sort.IsSorted(shuffled), ShouldBeFalse)
+		for str, expected := range map[string][]string{
+			"^1.0":                {"1.0.0", "1.2.0", "1.2.3", "1.2.3-p1", "1.3.0"},
+			">=1.2.3-beta <1.3":   {"1.2.3-beta", "1.2.3", "1.2.3-p1"},
+			"1.2.3":               {"1.2.3", "1.2.3-p1"},
+			">1.0.0-rc1 <=1.0.0":  {"1.0.0"},
+			">=1.0.0-rc1 <=1.0.0": {"1.0.0-rc1", "1.0.0"},
+			"<2.0.0":              {"0.9.0", "1.0.0", "1.2.0", "1.2.3", "1.2.3-p1", "1.3.0"},
+			">=2":                 {"2.0.0", "2.1.0"},
+			"":                    {"0.9.0", "1.0.0", "1.2.0", "1.2.3", "1.2.3-p1", "1.3.0", "2.0.0", "2.1.0"},
+			"~3":                  {},
+		} {
+			r, err := NewRange([]byte(str))
+			So(err, ShouldBeNil)
+
+			Convey(str+" in sorted Versions", func() {
+				So(asStrings(r.Filter(sorted)), ShouldResemble, expected)
+				if len(expected) == 0 {
+					So(r.MaxSatisfying(sorted), ShouldBeNil)
+					So(r.MinSatisfying(sorted), ShouldBeNil)
+					return
+				}
+				So(r.MaxSatisfying(sorted).String(), ShouldEqual, expected[len(expected)-1])
+				So(r.MinSatisfying(sorted).String(), ShouldEqual, expected[0])
+			})
+
+			Convey(str+" in shuffled Versions", func() {
+				filtered := r.Filter(shuffled)
+				So(filtered, ShouldHaveLength, len(expected))
+				for i := 1; i < len(filtered); i++ { // Keeps the order.
+					So(indexOfPtr(shuffled, filtered[i-1]), ShouldBeLessThan, indexOfPtr(shuffled, filtered[i]))
+				}
+				if len(expected) == 0 {
+					So(r.MaxSatisfying(shuffled), ShouldBeNil)
+					So(r.MinSatisfying(shuffled), ShouldBeNil)
+					return
+				}
+				So(r.MaxSatisfying(shuffled).String(), ShouldEqual, expected[len(expected)-1])
+				So(r.MinSatisfying(shuffled).String(), ShouldEqual, expected[0])
+			})
+		}
+	})
+
+	Convey("MaxSatisfying and MinSatisfying return nil for no Versions", t, func() {
+		r, _ := NewRange([]byte("^1.0"))
+		So(r.MaxSatisfying(nil), ShouldBeNil)
+		So(r.MinSatisfying(VersionPtrs{nil}), ShouldBeNil)
+		So(r.Filter(nil), ShouldBeEmpty)
+	})
+}
+
+func indexOfPtr(p VersionPtrs, v *Version) int {
+	for i := range p {
+		if p[i] == v {
+			return i
+		}
+	}
+	return -1
+}
+
 var benchR, benchRErr = NewRange([]byte(">=1.2.3 <=1.3.0"))
 
 func BenchmarkSemverNewRange(b *testing.B) {
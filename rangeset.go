@@ -0,0 +1,68 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"unicode"
+)
+
+// RangeSet is a union of Ranges, such as "^1.2 || >=3.1 <3.5".
+//
+// A Version is in the RangeSet if it is in any of its Ranges.
+// An empty RangeSet contains nothing.
+type RangeSet []Range
+
+var rangeSetSeparator = []byte("||")
+
+// NewRangeSet translates a string of Ranges separated by "||" into a RangeSet.
+//
+// Every alternative is read by NewRange. Following NodeJS' notation
+// an empty alternative, like in "1.x || ", contains everything.
+func NewRangeSet(str []byte) (RangeSet, error) {
+	return newRangeSet(str, newVocabularyVersion)
+}
+
+// newRangeSet is NewRangeSet with the given function to read the Versions of boundaries.
+func newRangeSet(str []byte, parse func([]byte) (Version, error)) (RangeSet, error) {
+	alternatives := bytes.Split(str, rangeSetSeparator)
+	set := make(RangeSet, len(alternatives))
+	var offset int
+	for i, alternative := range alternatives {
+		r, err := newRange(bytes.TrimSpace(alternative), parse)
+		if err != nil {
+			leadingSpace := len(alternative) - len(bytes.TrimLeftFunc(alternative, unicode.IsSpace))
+			return nil, atOffset(err, str, offset+leadingSpace)
+		}
+		set[i] = r
+		offset += len(alternative) + len(rangeSetSeparator)
+	}
+	return set, nil
+}
+
+// Contains returns true if a Version is inside any of the Ranges.
+//
+// If in doubt use IsSatisfiedBy.
+func (s RangeSet) Contains(v Version) bool {
+	for i := range s {
+		if s[i].Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSatisfiedBy works like Contains,
+// but rejects pre-releases unless one Range admits them by its bounds.
+//
+// See Range's IsSatisfiedBy for details.
+func (s RangeSet) IsSatisfiedBy(v Version) bool {
+	for i := range s {
+		if s[i].IsSatisfiedBy(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRangeSetConstruction(t *testing.T) {
+	Convey("NewRangeSet works with…", t, func() {
+		Convey("a single Range", func() {
+			set, err := NewRangeSet([]byte("^1.2"))
+			So(err, ShouldBeNil)
+			So(set, ShouldHaveLength, 1)
+			r, _ := NewRange([]byte("^1.2"))
+			testIfResembles(set[0], r)
+		})
+
+		Convey("^1.2 || >=3.1 <3.5", func() {
+			set, err := NewRangeSet([]byte("^1.2 || >=3.1 <3.5"))
+			So(err, ShouldBeNil)
+			So(set, ShouldHaveLength, 2)
+			So(set[0], hasLowerBound, MustParse("1.2.0"))
+			So(set[0], isRightClosedBy, MustParse("2.0.0"))
+			So(set[1], hasLowerBound, MustParse("3.1.0"))
+			So(set[1], isRightClosedBy, MustParse("3.5.0"))
+		})
+
+		Convey("no whitespace around the separator", func() {
+			set, err := NewRangeSet([]byte("1.2.3||2.x||<0.5"))
+			So(err, ShouldBeNil)
+			So(set, ShouldHaveLength, 3)
+		})
+
+		Convey("an empty alternative that contains everything", func() {
+			set, err := NewRangeSet([]byte("1.x || "))
+			So(err, ShouldBeNil)
+			So(set, ShouldHaveLength, 2)
+			So(set.Contains(MustParse("7.0.0")), ShouldBeTrue)
+		})
+	})
+
+	Convey("NewRangeSet rejects…", t, func() {
+		for _, s := range []string{
+			"^1.2 || ^1.2.3.4.5", "1.x || - 1.0", "1.0 | 2.0",
+		} {
+			Convey(s, func() {
+				_, err := NewRangeSet([]byte(s))
+				So(err, ShouldNotBeNil)
+			})
+		}
+	})
+
+	Convey("NewRange refuses unions", t, func() {
+		_, err := NewRange([]byte(">=1 || <0.5"))
+		So(errors.Is(err, errUnionInRange), ShouldBeTrue)
+	})
+}
+
+func TestRangeSetContains(t *testing.T) {
+	Convey("Given the RangeSet ^1.2 || >=3.1 <3.5", t, func() {
+		set, _ := NewRangeSet([]byte("^1.2 || >=3.1 <3.5"))
+
+		Convey("accept Versions in either Range", func() {
+			for _, v := range []string{"1.2.0", "1.9.9", "3.1.0", "3.4.99"} {
+				So(set.Contains(MustParse(v)), ShouldBeTrue)
+				So(set.IsSatisfiedBy(MustParse(v)), ShouldBeTrue)
+			}
+		})
+
+		Convey("reject Versions in between or outside", func() {
+			for _, v := range []string{"1.1.9", "2.0.0", "3.0.9", "3.5.0", "4.0.0"} {
+				So(set.Contains(MustParse(v)), ShouldBeFalse)
+			}
+		})
+
+		Convey("reject pre-releases in IsSatisfiedBy", func() {
+			So(set.Contains(MustParse("3.2.0-beta")), ShouldBeTrue)
+			So(set.IsSatisfiedBy(MustParse("3.2.0-beta")), ShouldBeFalse)
+		})
+	})
+
+	Convey("Pre-releases satisfy if one Range admits them", t, func() {
+		set, _ := NewRangeSet([]byte("^1.2 || >=2.0.0-rc1 <3"))
+		So(set.IsSatisfiedBy(MustParse("2.0.0-rc2")), ShouldBeTrue)
+		So(set.IsSatisfiedBy(MustParse("1.3.0-rc2")), ShouldBeFalse)
+	})
+
+	Convey("An empty RangeSet contains nothing", t, func() {
+		So(RangeSet{}.Contains(MustParse("1.0.0")), ShouldBeFalse)
+	})
+
+	Convey("Satisfies understands unions", t, func() {
+		ok, err := Satisfies("3.2.0", "^1.2 || >=3.1 <3.5")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+	})
+}
@@ -0,0 +1,408 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Errors that are thrown when reading an RPMVersion or RPMRange.
+const (
+	errInvalidRPMVersion InvalidStringValue = "Given string does not resemble an RPM version"
+	errInvalidRPMType    InvalidStringValue = "Cannot read this type into an RPMVersion"
+	errInvalidRPMRange   InvalidStringValue = "Given string does not resemble a range of RPM versions"
+)
+
+// RPMVersion is the "epoch:version-release", or EVR, of an RPM package, such as "1:4.18.0-305.el8".
+type RPMVersion struct {
+	epoch    int
+	hasEpoch bool
+	version  string
+	release  string
+}
+
+// NewRPMVersion reads an EVR, in which both the epoch and release are optional.
+//
+// Version and release consist of letters, digits, and any of "._+~^",
+// which rpmvercmp gives meaning in Compare.
+func NewRPMVersion(str []byte) (RPMVersion, error) {
+	var v RPMVersion
+	versionStart, versionEnd := 0, len(str)
+	if idx := bytes.IndexByte(str, ':'); idx >= 0 {
+		if n := countDigits(str[:idx]); n < idx || n == 0 {
+			return RPMVersion{}, newParseError(errInvalidRPMVersion, str, n, "a number as epoch")
+		}
+		epoch, err := strconv.ParseInt(string(str[:idx]), 10, 32)
+		if err != nil {
+			return RPMVersion{}, newParseError(errInvalidRPMVersion, str, 0, "an epoch that fits into 31 bits")
+		}
+		v.epoch, v.hasEpoch, versionStart = int(epoch), true, idx+1
+	}
+	if idx := bytes.LastIndexByte(str, '-'); idx >= versionStart {
+		if n := countRPMBytes(str[idx+1:]); n == 0 || idx+1+n < len(str) {
+			return RPMVersion{}, newParseError(errInvalidRPMVersion, str, idx+1+n, "a letter, digit, or any of ._+~^")
+		}
+		v.release, versionEnd = string(str[idx+1:]), idx
+	}
+	if n := countRPMBytes(str[versionStart:versionEnd]); n == 0 || versionStart+n < versionEnd {
+		return RPMVersion{}, newParseError(errInvalidRPMVersion, str, versionStart+n, "a letter, digit, or any of ._+~^")
+	}
+	v.version = string(str[versionStart:versionEnd])
+	return v, nil
+}
+
+// ParseRPM is NewRPMVersion for strings.
+func ParseRPM(str string) (RPMVersion, error) {
+	return NewRPMVersion([]byte(str))
+}
+
+// countRPMBytes returns the length of the leading version or release.
+func countRPMBytes(str []byte) int {
+	for n, ch := range str {
+		if !isNumeric(ch) && !isSmallLetter(ch|0x20) && strings.IndexByte("._+~^", ch) < 0 {
+			return n
+		}
+	}
+	return len(str)
+}
+
+// Epoch returns the epoch, which is 0 if none has been given.
+func (v RPMVersion) Epoch() int {
+	return v.epoch
+}
+
+// Version returns the version, such as "4.18.0" in "1:4.18.0-305.el8".
+func (v RPMVersion) Version() string {
+	return v.version
+}
+
+// Release returns the release, such as "305.el8" in "1:4.18.0-305.el8",
+// or the empty string if there's none.
+func (v RPMVersion) Release() string {
+	return v.release
+}
+
+// Compare returns the signum of the difference between v and o, as rpm orders them:
+//
+//   1  if v > o
+//   0  if v == o
+//   -1 if v < o
+//
+// Epochs are compared as numbers, then versions and releases by rpmvercmp:
+// Both are split into runs of digits or letters, with anything else a separator.
+// Numbers are greater than letters, and a '~' sorts before anything, even the end,
+// whereas a '^' sorts after the end but before anything else:
+// 1.0~rc1 < 1.0 < 1.0^20210101 < 1.0.1.
+func (v RPMVersion) Compare(o RPMVersion) int {
+	return v.compare(o, true)
+}
+
+// compare is Compare, which skips the releases unless withRelease.
+func (v RPMVersion) compare(o RPMVersion, withRelease bool) int {
+	if v.epoch != o.epoch {
+		return signum(v.epoch - o.epoch)
+	}
+	if c := rpmvercmp(v.version, o.version); c != 0 || !withRelease {
+		return c
+	}
+	return rpmvercmp(v.release, o.release)
+}
+
+// Less is a convenience function for sorting.
+func (v *RPMVersion) Less(o *RPMVersion) bool {
+	return v.Compare(*o) < 0
+}
+
+// rpmvercmp compares two versions, or releases, as rpm does.
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	isSeparator := func(ch byte) bool {
+		return !isNumeric(ch) && !isSmallLetter(ch|0x20) && ch != '~' && ch != '^'
+	}
+
+	var i, j int
+	for i < len(a) || j < len(b) {
+		for i < len(a) && isSeparator(a[i]) {
+			i++
+		}
+		for j < len(b) && isSeparator(b[j]) {
+			j++
+		}
+
+		// A tilde sorts before everything else.
+		if (i < len(a) && a[i] == '~') || (j < len(b) && b[j] == '~') {
+			switch {
+			case i >= len(a) || a[i] != '~':
+				return 1
+			case j >= len(b) || b[j] != '~':
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+		// A caret sorts after the end, but before anything else.
+		if (i < len(a) && a[i] == '^') || (j < len(b) && b[j] == '^') {
+			switch {
+			case i >= len(a):
+				return -1
+			case j >= len(b):
+				return 1
+			case a[i] != '^':
+				return 1
+			case b[j] != '^':
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		// Take a run of digits, or of letters.
+		isRun, isNumber := isNumeric, isNumeric(a[i])
+		if !isNumber {
+			isRun = func(ch byte) bool { return isSmallLetter(ch | 0x20) }
+		}
+		x, y := i, j
+		for i < len(a) && isRun(a[i]) {
+			i++
+		}
+		for j < len(b) && isRun(b[j]) {
+			j++
+		}
+		if y == j { // Numbers are newer than letters.
+			if isNumber {
+				return 1
+			}
+			return -1
+		}
+
+		var c int
+		if isNumber {
+			c = compareDecimals(a[x:i], b[y:j])
+		} else {
+			c = strings.Compare(a[x:i], b[y:j])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i < len(a):
+		return 1
+	}
+	return -1
+}
+
+// serialize returns the EVR as it has been read.
+func (v RPMVersion) serialize() []byte {
+	target := make([]byte, 0, len(v.version)+len(v.release)+16)
+	if v.hasEpoch {
+		target = strconv.AppendInt(target, int64(v.epoch), 10)
+		target = append(target, ':')
+	}
+	target = append(target, v.version...)
+	if v.release != "" {
+		target = append(target, '-')
+		target = append(target, v.release...)
+	}
+	return target
+}
+
+// String returns the string representation of v.
+func (v RPMVersion) String() string {
+	return string(v.serialize())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v RPMVersion) MarshalJSON() ([]byte, error) {
+	return quoteJSON(v.String()), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v RPMVersion) MarshalText() ([]byte, error) {
+	return v.serialize(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *RPMVersion) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONText(b, v.UnmarshalText)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *RPMVersion) UnmarshalText(b []byte) error {
+	rv, err := NewRPMVersion(b)
+	if err != nil {
+		return err
+	}
+	*v = rv
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *RPMVersion) Scan(src interface{}) error {
+	return scanText(src, v.UnmarshalText, errInvalidRPMType)
+}
+
+// Value implements the driver.Valuer interface, as found in database/sql.
+func (v RPMVersion) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// RPMVersionPtrs represents an array with elements derived from RPMVersion.
+// Use it to sort them, in the order rpm does.
+type RPMVersionPtrs []*RPMVersion
+
+// Len implements the sort.Interface.
+func (p RPMVersionPtrs) Len() int {
+	return len(p)
+}
+
+// Swap implements the sort.Interface.
+func (p RPMVersionPtrs) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements the sort.Interface.
+func (p RPMVersionPtrs) Less(i, j int) bool {
+	if p[i] == nil {
+		return false
+	} else if p[j] == nil {
+		return true
+	}
+	return p[i].Less(p[j])
+}
+
+// Sort reorders the pointers so that the RPMVersions appear in ascending order.
+// Any nil pointers go last.
+func (p RPMVersionPtrs) Sort() {
+	sort.Stable(p)
+}
+
+// RPMRange is a subset of all RPMVersions, with an optional lower and upper boundary,
+// such as ">= 4.18.0-305 < 4.19".
+type RPMRange struct {
+	lower       RPMVersion
+	upper       RPMVersion
+	hasLower    bool
+	equalsLower bool
+	hasUpper    bool
+	equalsUpper bool
+}
+
+// NewRPMRange reads up to two comparisons, separated by space or comma,
+// as found in the dependencies of RPM packages: "= 1:2.3-4", ">= 4.18.0-305 < 4.19".
+// The operators are "<", "<=", "=", ">=", and ">", optionally followed by whitespace.
+//
+// Like rpm does, releases are only compared if both the boundary and RPMVersion have one,
+// hence "= 4.18.0" includes 4.18.0-305.el8.
+// The empty string contains everything.
+func NewRPMRange(str []byte) (RPMRange, error) {
+	var r RPMRange
+	idx := 0
+	for {
+		for idx < len(str) && (str[idx] == ' ' || str[idx] == ',') {
+			idx++
+		}
+		if idx >= len(str) {
+			return r, nil
+		}
+
+		start := idx
+		for idx < len(str) && strings.IndexByte("<=>", str[idx]) >= 0 {
+			idx++
+		}
+		operator := string(str[start:idx])
+		switch operator {
+		case "<", "<=", "=", "==", ">=", ">":
+		default:
+			return RPMRange{}, newParseError(errInvalidRPMRange, str, start, "one of <, <=, =, >=, or >")
+		}
+		for idx < len(str) && str[idx] == ' ' {
+			idx++
+		}
+
+		versionStart := idx
+		for idx < len(str) && str[idx] != ' ' && str[idx] != ',' {
+			idx++
+		}
+		v, err := NewRPMVersion(str[versionStart:idx])
+		if err != nil {
+			return RPMRange{}, atOffset(err, str, versionStart)
+		}
+
+		isLower, isUpper := operator[0] == '>' || operator[0] == '=', operator[0] == '<' || operator[0] == '='
+		if (isLower && r.hasLower) || (isUpper && r.hasUpper) {
+			return RPMRange{}, newParseError(errInvalidRPMRange, str, start, "at most one lower and upper boundary")
+		}
+		isInclusive := strings.IndexByte(operator, '=') >= 0
+		if isLower {
+			r.lower, r.hasLower, r.equalsLower = v, true, isInclusive
+		}
+		if isUpper {
+			r.upper, r.hasUpper, r.equalsUpper = v, true, isInclusive
+		}
+	}
+}
+
+// ParseRPMRange is NewRPMRange for strings.
+func ParseRPMRange(str string) (RPMRange, error) {
+	return NewRPMRange([]byte(str))
+}
+
+// Contains returns true if the RPMVersion is inside this RPMRange.
+func (r RPMRange) Contains(v RPMVersion) bool {
+	if r.hasLower {
+		c := v.compare(r.lower, r.lower.release != "" && v.release != "")
+		if c < 0 || (c == 0 && !r.equalsLower) {
+			return false
+		}
+	}
+	if r.hasUpper {
+		c := v.compare(r.upper, r.upper.release != "" && v.release != "")
+		if c > 0 || (c == 0 && !r.equalsUpper) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the RPMRange in the notation NewRPMRange reads.
+func (r RPMRange) String() string {
+	switch {
+	case !r.hasLower && !r.hasUpper:
+		return ""
+	case r.hasLower && r.hasUpper && r.equalsLower && r.equalsUpper && r.lower == r.upper:
+		return "= " + r.lower.String()
+	}
+	var parts []string
+	if r.hasLower {
+		if r.equalsLower {
+			parts = append(parts, ">= "+r.lower.String())
+		} else {
+			parts = append(parts, "> "+r.lower.String())
+		}
+	}
+	if r.hasUpper {
+		if r.equalsUpper {
+			parts = append(parts, "<= "+r.upper.String())
+		} else {
+			parts = append(parts, "< "+r.upper.String())
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,229 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustParseRPM(str string) RPMVersion {
+	v, err := ParseRPM(str)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+func TestRpmvercmp(t *testing.T) {
+	// These are from rpm's own test suite, tests/rpmvercmp.at
+	Convey("rpmvercmp works with…", t, FailureContinues, func() {
+		for _, tc := range []struct {
+			a, b     string
+			expected int
+		}{
+			{"1.0", "1.0", 0}, {"1.0", "2.0", -1}, {"2.0", "1.0", 1},
+			{"2.0.1", "2.0.1", 0}, {"2.0", "2.0.1", -1}, {"2.0.1", "2.0", 1},
+			{"2.0.1a", "2.0.1a", 0}, {"2.0.1a", "2.0.1", 1}, {"2.0.1", "2.0.1a", -1},
+			{"5.5p1", "5.5p1", 0}, {"5.5p1", "5.5p2", -1}, {"5.5p2", "5.5p1", 1},
+			{"5.5p10", "5.5p10", 0}, {"5.5p1", "5.5p10", -1}, {"5.5p10", "5.5p1", 1},
+			{"10xyz", "10.1xyz", -1}, {"10.1xyz", "10xyz", 1},
+			{"xyz10", "xyz10", 0}, {"xyz10", "xyz10.1", -1}, {"xyz10.1", "xyz10", 1},
+			{"xyz.4", "xyz.4", 0}, {"xyz.4", "8", -1}, {"8", "xyz.4", 1},
+			{"xyz.4", "2", -1}, {"2", "xyz.4", 1},
+			{"5.5p2", "5.6p1", -1}, {"5.6p1", "5.5p2", 1},
+			{"5.6p1", "6.5p1", -1}, {"6.5p1", "5.6p1", 1},
+			{"6.0.rc1", "6.0", 1}, {"6.0", "6.0.rc1", -1},
+			{"10b2", "10a1", 1}, {"10a2", "10b2", -1},
+			{"1.0aa", "1.0aa", 0}, {"1.0a", "1.0aa", -1}, {"1.0aa", "1.0a", 1},
+			{"10.0001", "10.0001", 0}, {"10.0001", "10.1", 0}, {"10.1", "10.0001", 0},
+			{"10.0001", "10.0039", -1}, {"10.0039", "10.0001", 1},
+			{"4.999.9", "5.0", -1}, {"5.0", "4.999.9", 1},
+			{"20101121", "20101121", 0}, {"20101121", "20101122", -1}, {"20101122", "20101121", 1},
+			{"2_0", "2_0", 0}, {"2.0", "2_0", 0}, {"2_0", "2.0", 0},
+			{"a", "a", 0}, {"a+", "a+", 0}, {"a+", "a_", 0}, {"a_", "a+", 0},
+			{"+a", "+a", 0}, {"+a", "_a", 0}, {"_a", "+a", 0},
+			{"+_", "+_", 0}, {"_+", "+_", 0}, {"_+", "_+", 0}, {"+", "_", 0}, {"_", "+", 0},
+			{"1.0~rc1", "1.0~rc1", 0}, {"1.0~rc1", "1.0", -1}, {"1.0", "1.0~rc1", 1},
+			{"1.0~rc1", "1.0~rc2", -1}, {"1.0~rc2", "1.0~rc1", 1},
+			{"1.0~rc1~git123", "1.0~rc1~git123", 0}, {"1.0~rc1~git123", "1.0~rc1", -1}, {"1.0~rc1", "1.0~rc1~git123", 1},
+			{"1.0^", "1.0^", 0}, {"1.0^", "1.0", 1}, {"1.0", "1.0^", -1},
+			{"1.0^git1", "1.0^git1", 0}, {"1.0^git1", "1.0", 1}, {"1.0", "1.0^git1", -1},
+			{"1.0^git1", "1.0^git2", -1}, {"1.0^git2", "1.0^git1", 1},
+			{"1.0^git1", "1.01", -1}, {"1.01", "1.0^git1", 1},
+			{"1.0^20160101", "1.0^20160101", 0}, {"1.0^20160101", "1.0.1", -1}, {"1.0.1", "1.0^20160101", 1},
+			{"1.0^20160101^git1", "1.0^20160101^git1", 0},
+			{"1.0^20160102", "1.0^20160101^git1", 1}, {"1.0^20160101^git1", "1.0^20160102", -1},
+			{"1.0~rc1^git1", "1.0~rc1^git1", 0}, {"1.0~rc1^git1", "1.0~rc1", 1}, {"1.0~rc1", "1.0~rc1^git1", -1},
+			{"1.0^git1~pre", "1.0^git1~pre", 0}, {"1.0^git1", "1.0^git1~pre", 1}, {"1.0^git1~pre", "1.0^git1", -1},
+		} {
+			Convey(tc.a+" and "+tc.b, func() {
+				So(rpmvercmp(tc.a, tc.b), ShouldEqual, tc.expected)
+			})
+		}
+	})
+}
+
+func TestNewRPMVersion(t *testing.T) {
+	Convey("NewRPMVersion reads…", t, FailureContinues, func() {
+		for str, parts := range map[string][3]interface{}{
+			"4.18.0":             {0, "4.18.0", ""},
+			"4.18.0-305.el8":     {0, "4.18.0", "305.el8"},
+			"1:4.18.0-305.el8":   {1, "4.18.0", "305.el8"},
+			"0:1.0~rc1^git1-1":   {0, "1.0~rc1^git1", "1"},
+			"2.3.4_p1-0.1.fc35+": {0, "2.3.4_p1", "0.1.fc35+"},
+		} {
+			Convey(str, func() {
+				v, err := ParseRPM(str)
+				So(err, ShouldBeNil)
+				So(v.Epoch(), ShouldEqual, parts[0])
+				So(v.Version(), ShouldEqual, parts[1])
+				So(v.Release(), ShouldEqual, parts[2])
+				So(v.String(), ShouldEqual, str)
+			})
+		}
+	})
+
+	Convey("NewRPMVersion rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"":              0,
+			":1.0":          0,
+			"1:":            2,
+			"x:1.0":         0,
+			"1.0-":          4,
+			"1.0-1-2":       3,
+			"1.0 ":          3,
+			"1.0-el8!":      7,
+			"99999999999:1": 0,
+		} {
+			Convey(str, func() {
+				_, err := ParseRPM(str)
+				So(errors.Is(err, errInvalidRPMVersion), ShouldBeTrue)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
+
+func TestRPMVersionOrder(t *testing.T) {
+	Convey("RPMVersions are ordered by epoch, version, then release", t, func() {
+		ordered := []string{
+			"4.18.0~rc1-1", "4.18.0", "4.18.0-305.el8", "4.18.0-305.3.1.el8", "4.18.0^20210101-1",
+			"4.18.1-1", "5.0-1", "1:0.1-1",
+		}
+		for i := 0; i+1 < len(ordered); i++ {
+			a, b := mustParseRPM(ordered[i]), mustParseRPM(ordered[i+1])
+			So(a.Compare(b), ShouldEqual, -1)
+			So(b.Compare(a), ShouldEqual, 1)
+			So(a.Less(&b), ShouldBeTrue)
+		}
+		So(mustParseRPM("0:1.0-1").Compare(mustParseRPM("1.0-1")), ShouldEqual, 0)
+
+		Convey("also by RPMVersionPtrs, nil last", func() {
+			ptrs := RPMVersionPtrs{nil}
+			for i := len(ordered) - 1; i >= 0; i-- {
+				v := mustParseRPM(ordered[i])
+				ptrs = append(ptrs, &v)
+			}
+			ptrs.Sort()
+			for i := range ordered {
+				So(ptrs[i].String(), ShouldEqual, ordered[i])
+			}
+			So(ptrs[len(ordered)], ShouldBeNil)
+		})
+	})
+
+	Convey("RPMVersion is written as it's read…", t, FailureContinues, func() {
+		Convey("keeping an explicit zero epoch, as rpm -q --qf '%{EVR}' could have returned it", func() {
+			v := mustParseRPM("0:1.0-1")
+			out, err := json.Marshal(v)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, `"0:1.0-1"`)
+			So(v.Compare(mustParseRPM("1.0-1")), ShouldEqual, 0)
+		})
+
+		Convey("with tilde and caret intact", func() {
+			v := mustParseRPM("1:1.0~rc1^git1-2.fc39")
+			text, err := v.MarshalText()
+			So(err, ShouldBeNil)
+			So(string(text), ShouldEqual, "1:1.0~rc1^git1-2.fc39")
+			var back RPMVersion
+			So(json.Unmarshal(append(append([]byte{'"'}, text...), '"'), &back), ShouldBeNil)
+			So(back, ShouldResemble, v)
+		})
+
+		Convey("and is left alone by JSON null", func() {
+			v := mustParseRPM("4.18.0-305.el8")
+			So(json.Unmarshal([]byte("null"), &v), ShouldBeNil)
+			So(v.Release(), ShouldEqual, "305.el8")
+		})
+	})
+
+	Convey("RPMVersion is scanned from databases, as in the rpmdb's EVR columns", t, func() {
+		v := mustParseRPM("1:4.18.0-305.el8")
+		val, _ := v.Value()
+		var back RPMVersion
+		So(back.Scan(val), ShouldBeNil)
+		So(back, ShouldResemble, v)
+		So(errors.Is(back.Scan([]byte("1.0-1-2")), errInvalidRPMVersion), ShouldBeTrue)
+		So(back.Scan(3.14), ShouldEqual, errInvalidRPMType)
+	})
+}
+
+func TestRPMRange(t *testing.T) {
+	Convey("RPMRange contains…", t, FailureContinues, func() {
+		for str, versions := range map[string]map[string]bool{
+			"": {"0": true, "1:99": true},
+			">= 4.18.0-305 < 4.19": {
+				"4.18.0-240.el8": false, "4.18.0-305": true, "4.18.0-305.el8": true,
+				"4.18.9": true, "4.19": false, "4.19-1": false, "4.19~rc1": true,
+			},
+			"= 4.18.0":    {"4.18.0-1": true, "4.18.0-305.el8": true, "4.18.1": false, "1:4.18.0": false},
+			"=4.18.0-305": {"4.18.0-305": true, "4.18.0-306": false, "4.18.0": true},
+			">1.0,<=2.0":  {"1.0": false, "1.0-1": false, "1.0.1": true, "2.0-5": true, "2.0.1": false},
+			"<= 1:2.3":    {"1:2.3-9": true, "2:0.1": false, "99.0": true},
+		} {
+			r, err := ParseRPMRange(str)
+			So(err, ShouldBeNil)
+			for version, expected := range versions {
+				Convey(str+" and "+version, func() {
+					So(r.Contains(mustParseRPM(version)), ShouldEqual, expected)
+				})
+			}
+		}
+	})
+
+	Convey("RPMRange is written as it's read", t, func() {
+		for _, str := range []string{"", "= 1:4.18.0-305.el8", ">= 4.18.0-305 < 4.19", "> 1.0 <= 2.0"} {
+			r, err := ParseRPMRange(str)
+			So(err, ShouldBeNil)
+			So(r.String(), ShouldEqual, str)
+		}
+	})
+
+	Convey("NewRPMRange rejects, and tells where…", t, FailureContinues, func() {
+		for str, offset := range map[string]int{
+			"4.18":           0,
+			"=> 4.18":        0,
+			">= 4.18 >= 5":   8,
+			"= 4.18 < 5":     7,
+			">= 4.18 < 4.x!": 13,
+			">=":             2,
+		} {
+			Convey(str, func() {
+				_, err := ParseRPMRange(str)
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Input, ShouldEqual, str)
+				So(e.Offset, ShouldEqual, offset)
+			})
+		}
+	})
+}
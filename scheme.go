@@ -0,0 +1,357 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Errors that are thrown by Schemes.
+const (
+	errNoConstraints  InvalidStringValue = "This ecosystem has no notation for constraints on versions, only for versions"
+	errForeignVersion InvalidStringValue = "Given version has not been read by this Scheme"
+)
+
+// SchemeVersion is a version as a Scheme reads it, such as a Version or a DebianVersion.
+type SchemeVersion interface {
+	String() string
+}
+
+// Constraint is a constraint on versions as a Scheme reads it, such as a RangeSet or an RPMRange.
+type Constraint interface {
+	// IsSatisfiedBy is true if the version meets the constraint.
+	// Versions by any other Scheme never do.
+	IsSatisfiedBy(v SchemeVersion) bool
+
+	String() string
+}
+
+// Scheme is the notation and order of versions, and of constraints on them, of one ecosystem.
+//
+// Versions and Constraints of a Scheme are only meant for use with the same Scheme.
+type Scheme interface {
+	// ParseVersion reads a version.
+	ParseVersion(str string) (SchemeVersion, error)
+
+	// ParseConstraint reads a constraint on versions,
+	// or returns an error if the ecosystem has no notation for them.
+	ParseConstraint(str string) (Constraint, error)
+
+	// Compare returns the signum of the difference between a and b, which ParseVersion has returned,
+	// or an error if either is a version of any other Scheme.
+	Compare(a, b SchemeVersion) (int, error)
+
+	// Canonical returns the canonical string representation of a version,
+	// which can differ from how it has been written.
+	// That of a version of any other Scheme is what its String returns.
+	Canonical(v SchemeVersion) string
+}
+
+// scheme implements Scheme by functions, for the types of this package.
+type scheme struct {
+	parseVersion    func(str []byte) (SchemeVersion, error)
+	parseConstraint func(str []byte) (Constraint, error) // Can be nil.
+	compare         func(a, b SchemeVersion) (int, error)
+	canonical       func(v SchemeVersion) string // Can be nil, then String is used.
+}
+
+func (s scheme) ParseVersion(str string) (SchemeVersion, error) {
+	return s.parseVersion([]byte(str))
+}
+
+func (s scheme) ParseConstraint(str string) (Constraint, error) {
+	if s.parseConstraint == nil {
+		return nil, errNoConstraints
+	}
+	return s.parseConstraint([]byte(str))
+}
+
+func (s scheme) Compare(a, b SchemeVersion) (int, error) {
+	return s.compare(a, b)
+}
+
+func (s scheme) Canonical(v SchemeVersion) string {
+	if s.canonical == nil {
+		return v.String()
+	}
+	return s.canonical(v)
+}
+
+// constraint implements Constraint by a function.
+type constraint struct {
+	str           string
+	isSatisfiedBy func(v SchemeVersion) bool
+}
+
+func (c constraint) IsSatisfiedBy(v SchemeVersion) bool {
+	return c.isSatisfiedBy(v)
+}
+
+func (c constraint) String() string {
+	return c.str
+}
+
+var (
+	schemesMu sync.RWMutex
+	schemes   = map[string]Scheme{}
+)
+
+// RegisterScheme makes a Scheme available by the name of its ecosystem,
+// replacing any that has been registered by that name before.
+// It panics if s is nil.
+func RegisterScheme(name string, s Scheme) {
+	if s == nil {
+		panic("semver: RegisterScheme of a nil Scheme")
+	}
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+	schemes[name] = s
+}
+
+// SchemeFor returns the Scheme registered by the name of the ecosystem, or nil if there's none.
+//
+// Registered are: "semver", which is this package's notation as NewRangeSet reads it:
+// versions by the fixed vocabulary of NewVersion, and any other pre-release as NewVersionWithIdentifiers does,
+// "npm", which reads versions and those in its RangeSets by NewVersionWithIdentifiers,
+// "cargo", which does likewise with constraints of its own,
+// "composer", which reads versions as "semver" does,
+// "alpine" or "apk", "arch" or "pacman", "debian" or "deb", "rpm", "gentoo" with Atoms as constraints,
+// "pypi", "maven", "go" for modules, "rubygems", and "nuget".
+// The empty name is that of the default Scheme, "semver".
+func SchemeFor(name string) Scheme {
+	if name == "" {
+		name = "semver"
+	}
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	return schemes[name]
+}
+
+// SchemeNames returns the names of all registered Schemes, in ascending order.
+func SchemeNames() []string {
+	schemesMu.RLock()
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	schemesMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// compareByMethod returns the compare of a Scheme whose versions are of the type of sample,
+// which has a method Compare that takes another of that type and returns an int.
+func compareByMethod(sample SchemeVersion) func(a, b SchemeVersion) (int, error) {
+	typ := reflect.TypeOf(sample)
+	method, found := typ.MethodByName("Compare")
+	if !found || method.Type.NumIn() != 2 || method.Type.In(1) != typ ||
+		method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.Int {
+		panic("semver: " + typ.String() + " has no method Compare(" + typ.String() + ") int")
+	}
+	return func(a, b SchemeVersion) (int, error) {
+		if reflect.TypeOf(a) != typ || reflect.TypeOf(b) != typ {
+			return 0, errForeignVersion
+		}
+		result := method.Func.Call([]reflect.Value{reflect.ValueOf(a), reflect.ValueOf(b)})
+		return int(result[0].Int()), nil
+	}
+}
+
+func init() {
+	compareVersions := func(a, b SchemeVersion) (int, error) {
+		v, ok := a.(Version)
+		o, isSame := b.(Version)
+		if !ok || !isSame {
+			return 0, errForeignVersion
+		}
+		return Compare(&v, &o), nil
+	}
+	parseRangeSetWith := func(parse func([]byte) (Version, error)) func([]byte) (Constraint, error) {
+		return func(str []byte) (Constraint, error) {
+			set, err := newRangeSet(str, parse)
+			if err != nil {
+				return nil, err
+			}
+			parts := make([]string, len(set))
+			for i := range set {
+				parts[i] = set[i].String()
+			}
+			return constraint{strings.Join(parts, " || "), func(v SchemeVersion) bool {
+				ver, ok := v.(Version)
+				return ok && set.IsSatisfiedBy(ver)
+			}}, nil
+		}
+	}
+	parseVersionWithIdentifiers := func(str []byte) (SchemeVersion, error) {
+		return NewVersionWithIdentifiers(str)
+	}
+	parseVocabularyVersion := func(str []byte) (SchemeVersion, error) {
+		return newVocabularyVersion(str)
+	}
+
+	RegisterScheme("semver", scheme{
+		parseVersion:    parseVocabularyVersion,
+		parseConstraint: parseRangeSetWith(newVocabularyVersion),
+		compare:         compareVersions,
+	})
+	RegisterScheme("npm", scheme{
+		parseVersion:    parseVersionWithIdentifiers,
+		parseConstraint: parseRangeSetWith(NewVersionWithIdentifiers),
+		compare:         compareVersions,
+	})
+	RegisterScheme("cargo", scheme{
+		parseVersion: parseVersionWithIdentifiers,
+		parseConstraint: func(str []byte) (Constraint, error) {
+			r, err := NewCargoRange(str)
+			if err != nil {
+				return nil, err
+			}
+			return constraint{r.String(), func(v SchemeVersion) bool {
+				ver, ok := v.(Version)
+				return ok && r.IsSatisfiedBy(ver)
+			}}, nil
+		},
+		compare: compareVersions,
+	})
+	RegisterScheme("composer", scheme{
+		parseVersion: parseVocabularyVersion,
+		parseConstraint: func(str []byte) (Constraint, error) {
+			c, err := NewComposerConstraint(str)
+			if err != nil {
+				return nil, err
+			}
+			return constraint{c.String(), func(v SchemeVersion) bool {
+				ver, ok := v.(Version)
+				return ok && c.IsSatisfiedBy(ver)
+			}}, nil
+		},
+		compare: compareVersions,
+	})
+
+	apk := scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewApkVersion(str) },
+		compare:      compareByMethod(ApkVersion{}),
+	}
+	RegisterScheme("alpine", apk)
+	RegisterScheme("apk", apk)
+
+	pacman := scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewPacmanVersion(str) },
+		compare:      compareByMethod(PacmanVersion{}),
+	}
+	RegisterScheme("arch", pacman)
+	RegisterScheme("pacman", pacman)
+
+	debian := scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewDebianVersion(str) },
+		compare:      compareByMethod(DebianVersion{}),
+	}
+	RegisterScheme("debian", debian)
+	RegisterScheme("deb", debian)
+
+	RegisterScheme("rpm", scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewRPMVersion(str) },
+		parseConstraint: func(str []byte) (Constraint, error) {
+			r, err := NewRPMRange(str)
+			if err != nil {
+				return nil, err
+			}
+			return constraint{r.String(), func(v SchemeVersion) bool {
+				ver, ok := v.(RPMVersion)
+				return ok && r.Contains(ver)
+			}}, nil
+		},
+		compare: compareByMethod(RPMVersion{}),
+	})
+	RegisterScheme("gentoo", scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewGentooVersion(str) },
+		parseConstraint: func(str []byte) (Constraint, error) {
+			a, err := NewAtom(str)
+			if err != nil {
+				return nil, err
+			}
+			return constraint{a.String(), func(v SchemeVersion) bool {
+				ver, ok := v.(GentooVersion)
+				return ok && a.IsSatisfiedBy(ver)
+			}}, nil
+		},
+		compare: compareByMethod(GentooVersion{}),
+	})
+	RegisterScheme("pypi", scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewPEP440Version(str) },
+		parseConstraint: func(str []byte) (Constraint, error) {
+			set, err := NewPEP440SpecifierSet(str)
+			if err != nil {
+				return nil, err
+			}
+			return constraint{set.String(), func(v SchemeVersion) bool {
+				ver, ok := v.(PEP440Version)
+				return ok && set.IsSatisfiedBy(ver)
+			}}, nil
+		},
+		compare: compareByMethod(PEP440Version{}),
+	})
+	RegisterScheme("maven", scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewMavenVersion(str) },
+		parseConstraint: func(str []byte) (Constraint, error) {
+			r, err := NewMavenRange(str)
+			if err != nil {
+				return nil, err
+			}
+			return constraint{r.String(), func(v SchemeVersion) bool {
+				ver, ok := v.(MavenVersion)
+				return ok && r.Contains(ver)
+			}}, nil
+		},
+		compare: compareByMethod(MavenVersion{}),
+		canonical: func(v SchemeVersion) string {
+			if mv, ok := v.(MavenVersion); ok {
+				return mv.Canonical()
+			}
+			return v.String()
+		},
+	})
+	RegisterScheme("go", scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewModuleVersion(str) },
+		compare:      compareByMethod(ModuleVersion{}),
+		canonical: func(v SchemeVersion) string {
+			if mv, ok := v.(ModuleVersion); ok {
+				return mv.Canonical()
+			}
+			return v.String()
+		},
+	})
+	RegisterScheme("rubygems", scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewGemVersion(str) },
+		parseConstraint: func(str []byte) (Constraint, error) {
+			r, err := NewGemRequirement(str)
+			if err != nil {
+				return nil, err
+			}
+			return constraint{r.String(), func(v SchemeVersion) bool {
+				ver, ok := v.(GemVersion)
+				return ok && r.IsSatisfiedBy(ver)
+			}}, nil
+		},
+		compare: compareByMethod(GemVersion{}),
+	})
+	RegisterScheme("nuget", scheme{
+		parseVersion: func(str []byte) (SchemeVersion, error) { return NewNuGetVersion(str) },
+		parseConstraint: func(str []byte) (Constraint, error) {
+			r, err := NewNuGetRange(str)
+			if err != nil {
+				return nil, err
+			}
+			return constraint{r.String(), func(v SchemeVersion) bool {
+				ver, ok := v.(NuGetVersion)
+				return ok && r.IsSatisfiedBy(ver)
+			}}, nil
+		},
+		compare: compareByMethod(NuGetVersion{}),
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSchemes(t *testing.T) {
+	Convey("Schemes are registered by the names of ecosystems", t, func() {
+		So(SchemeNames(), ShouldContain, "semver")
+		for _, name := range []string{
+			"semver", "npm", "cargo", "composer", "alpine", "apk", "arch", "pacman", "debian", "deb", "rpm",
+			"gentoo", "pypi", "maven", "go", "rubygems", "nuget",
+		} {
+			So(SchemeFor(name), ShouldNotBeNil)
+			So(SchemeNames(), ShouldContain, name)
+		}
+		So(SchemeFor("cobol"), ShouldBeNil)
+
+		Convey("with the default by the empty name", func() {
+			_, err := SchemeFor("").ParseVersion("1.0.0-rc1")
+			So(err, ShouldBeNil)
+			_, err = SchemeFor("").ParseVersion("1.0.0-x.7.z.92")
+			So(err, ShouldBeNil)
+			_, err = SchemeFor("").ParseVersion("1.0.0-x..7")
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Schemes read constraints, and tell what satisfies them…", t, FailureContinues, func() {
+		for name, constraints := range map[string]map[string]map[string]bool{
+			"semver": {"^1.2 || >=3": {"1.2.0": true, "2.0.0": false, "3.1.0": true, "1.3.0-rc1": false}},
+			"npm": {
+				">=1.0.0-alpha.1 <2": {"1.0.0-alpha.2": true, "1.0.0-alpha.0": false, "2.0.0": false},
+				"<=1.0.0-rc1":        {"1.0.0-rc1": true, "1.0.0-rc2": false, "1.0.0-alpha.beta": true},
+				">1.0.0-alpha.1":     {"1.0.0-alpha.beta": true, "1.0.0-1": false},
+			},
+			"cargo":    {"0.3": {"0.3.9": true, "0.4.0": false}},
+			"composer": {"^1.2 | ^2.0@beta": {"2.1.0-beta1": true, "1.1.0": false}},
+			"rpm":      {">= 1.0-1 < 2": {"1.0-2": true, "2.0": false}},
+			"gentoo":   {">=dev-lang/go-1.16": {"1.17": true, "1.15_rc1": false}},
+			"pypi":     {"~=1.4.2, !=1.5.*": {"1.4.5": true, "1.5.0": false}},
+			"maven":    {"[1.0,2.0)": {"1.5": true, "2.0": false}},
+			"rubygems": {"~> 2.2": {"2.9": true, "3.0": false}},
+			"nuget":    {"1.*": {"1.5.0": true, "0.9.0": false}},
+		} {
+			s := SchemeFor(name)
+			for str, versions := range constraints {
+				c, err := s.ParseConstraint(str)
+				So(err, ShouldBeNil)
+				for version, expected := range versions {
+					Convey(name+": "+str+" and "+version, func() {
+						v, err := s.ParseVersion(version)
+						So(err, ShouldBeNil)
+						So(c.IsSatisfiedBy(v), ShouldEqual, expected)
+					})
+				}
+			}
+		}
+	})
+
+	Convey("Schemes write constraints in their notation", t, FailureContinues, func() {
+		for name, pair := range map[string][2]string{
+			"semver": {"^1.2 || >=3", ">=1.2.0 <2.0.0 || >=3.0.0"},
+			"cargo":  {"0.3", ">=0.3.0 <0.4.0"},
+			"nuget":  {"1.*", "[1.*, )"},
+			"pypi":   {"~=1.4.2, !=1.5.*", "~=1.4.2,!=1.5.*"},
+		} {
+			c, err := SchemeFor(name).ParseConstraint(pair[0])
+			So(err, ShouldBeNil)
+			So(c.String(), ShouldEqual, pair[1])
+		}
+	})
+
+	Convey("Schemes compare, and write versions canonically…", t, FailureContinues, func() {
+		for name, pair := range map[string][2]string{
+			"semver": {"v1.2", "1.2.0"},
+			"maven":  {"1.0.RC1", "1.0.rc-1"},
+			"nuget":  {"01.0", "1.0.0"},
+			"debian": {"0:1.0-1", "1.0-1"},
+		} {
+			Convey(name, func() {
+				s := SchemeFor(name)
+				v, err := s.ParseVersion(pair[0])
+				So(err, ShouldBeNil)
+				So(s.Canonical(v), ShouldEqual, pair[1])
+				o, _ := s.ParseVersion(pair[1])
+				result, err := s.Compare(v, o)
+				So(err, ShouldBeNil)
+				So(result, ShouldEqual, 0)
+			})
+		}
+
+		s := SchemeFor("alpine")
+		a, _ := s.ParseVersion("1.0_rc1")
+		b, _ := s.ParseVersion("1.0")
+		result, err := s.Compare(a, b)
+		So(err, ShouldBeNil)
+		So(result, ShouldEqual, -1)
+	})
+
+	Convey("Schemes keep to their own versions", t, FailureContinues, func() {
+		v, _ := SchemeFor("debian").ParseVersion("1.5")
+		c, _ := SchemeFor("semver").ParseConstraint(">=1")
+		So(c.IsSatisfiedBy(v), ShouldBeFalse)
+
+		for _, name := range SchemeNames() {
+			Convey(name+" won't compare a Debian version", func() {
+				s := SchemeFor(name)
+				o, err := s.ParseVersion("1.5")
+				if name == "go" {
+					o, err = s.ParseVersion("v1.5")
+				}
+				So(err, ShouldBeNil)
+				if name == "debian" || name == "deb" {
+					return
+				}
+				_, err = s.Compare(v, o)
+				So(err, ShouldEqual, errForeignVersion)
+				_, err = s.Compare(o, v)
+				So(err, ShouldEqual, errForeignVersion)
+			})
+		}
+
+		So(SchemeFor("maven").Canonical(v), ShouldEqual, "1.5")
+	})
+
+	Convey("Schemes without a notation for constraints tell so…", t, FailureContinues, func() {
+		for _, name := range []string{"go", "alpine", "apk", "arch", "pacman", "debian", "deb"} {
+			Convey(name, func() {
+				c, err := SchemeFor(name).ParseConstraint(">= 1.0")
+				So(c, ShouldBeNil)
+				So(err, ShouldEqual, errNoConstraints)
+			})
+		}
+	})
+
+	Convey("RegisterScheme adds to, or replaces in, the registry", t, func() {
+		RegisterScheme("yarn", SchemeFor("npm"))
+		_, err := SchemeFor("yarn").ParseVersion("1.0.0-x.7.z.92")
+		So(err, ShouldBeNil)
+		So(SchemeNames(), ShouldContain, "yarn")
+		So(func() { RegisterScheme("nil", nil) }, ShouldPanic)
+	})
+}
@@ -5,22 +5,27 @@
 package semver
 
 import (
-	"bytes"
+	"strconv"
+	"unicode/utf8"
 )
 
 // Errors that are thrown during parsing.
 const (
-	errInvalidVersionString InvalidStringValue = "Given string does not resemble a Version"
-	errTooManyColumns       InvalidStringValue = "Version consists of too many columns"
-	errVersionStringLength  InvalidStringValue = "Version is too long"
-	errInvalidBuildSuffix   InvalidStringValue = "Version has a '+' but no +buildNNN suffix"
-	errInvalidType          InvalidStringValue = "Cannot read this type into a Version"
-	errOutOfBounds          InvalidStringValue = "The source representation does not fit into a Version"
+	errInvalidVersionString  InvalidStringValue = "Given string does not resemble a Version"
+	errTooManyColumns        InvalidStringValue = "Version consists of too many columns"
+	errVersionStringLength   InvalidStringValue = "Version is too long"
+	errInvalidBuildSuffix    InvalidStringValue = "Version has a '+' but no +buildNNN suffix"
+	errInvalidType           InvalidStringValue = "Cannot read this type into a Version"
+	errInvalidRangeType      InvalidStringValue = "Cannot read this type into a Range"
+	errOutOfBounds           InvalidStringValue = "The source representation does not fit into a Version"
+	errUnionInRange          InvalidStringValue = "Range is a union of Ranges, use NewRangeSet"
+	errInvalidPreReleaseType InvalidStringValue = "Pre-release type must be one of alpha, beta, pre, or rc"
 )
 
-// alpha = -4, beta = -3, pre = -2, rc = -1, common = 0, revision = 1, patch = 2
+// identified = -5, alpha = -4, beta = -3, pre = -2, rc = -1, common = 0, revision = 1, patch = 2
 const (
-	alpha = iota - 4
+	identified = iota - 5 // The pre-release is not of the above, and kept in Version.preRelease.
+	alpha
 	beta
 	pre
 	rc
@@ -58,6 +63,8 @@ var releaseValue = map[string]int{
 var buildsuffix = []byte("+build")
 
 // InvalidStringValue instances are returned as error on any conversion failure.
+// Parsers return them wrapped in a *ParseError, hence check for one with errors.Is
+// instead of asserting the type, as in err.(InvalidStringValue), which fails on those.
 type InvalidStringValue string
 
 // Error implements the error interface.
@@ -67,6 +74,53 @@ func (e InvalidStringValue) Error() string { return string(e) }
 // This is used by some input validator packages.
 func (e InvalidStringValue) IsInvalid() bool { return true }
 
+// ParseError is returned if a Version or Range cannot be read from the given string.
+// It tells where that failed and what had been expected instead.
+//
+// Use errors.Is to check for the InvalidStringValue it wraps.
+type ParseError struct {
+	Input    string             // The string that has been read.
+	Offset   int                // Byte offset into Input of the offending character.
+	Char     rune               // The offending character, or 0 if the Input ended prematurely.
+	Expected string             // What would have been valid at Offset, such as "a digit".
+	Err      InvalidStringValue // The kind of failure.
+}
+
+func newParseError(kind InvalidStringValue, str []byte, offset int, expected string) *ParseError {
+	e := &ParseError{Input: string(str), Offset: offset, Expected: expected, Err: kind}
+	if offset < len(str) {
+		e.Char, _ = utf8.DecodeRune(str[offset:])
+	}
+	return e
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	msg := make([]byte, 0, 64+2*len(e.Input))
+	msg = append(msg, e.Err...)
+	if e.Offset < len(e.Input) {
+		msg = append(msg, ": unexpected "...)
+		msg = strconv.AppendQuoteRune(msg, e.Char)
+	} else {
+		msg = append(msg, ": unexpected end"...)
+	}
+	msg = append(msg, " at offset "...)
+	msg = strconv.AppendInt(msg, int64(e.Offset), 10)
+	msg = append(msg, " of "...)
+	msg = strconv.AppendQuote(msg, e.Input)
+	if e.Expected != "" {
+		msg = append(msg, ", expected "...)
+		msg = append(msg, e.Expected...)
+	}
+	return string(msg)
+}
+
+// Unwrap returns the InvalidStringValue, for errors.Is and errors.As.
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// IsInvalid satisfies a function IsInvalid().
+func (e *ParseError) IsInvalid() bool { return true }
+
 // Version represents a version:
 // Columns consisting of up to four unsigned integers (1.2.4.99)
 // optionally further divided into 'release' and 'specifier' (1.2-634.0-99.8).
@@ -75,9 +129,15 @@ type Version struct {
 	version [14]int32
 	build   int32
 	_       int32
+
+	// Any pre-release identifiers beyond the fixed vocabulary, with releaseType 'identified'.
+	preRelease string
+	// Any build metadata that is not in the form "+buildNNN", without the '+'.
+	buildMetadata string
 }
 
 // MustParse is NewVersion for strings, and panics on errors.
+// Like NewVersion it reads no build metadata other than "+buildNNN".
 //
 // Use this in tests or with constants, e. g. whenever you control the input.
 //
@@ -93,6 +153,9 @@ func MustParse(str string) Version {
 // NewVersion translates the given string, which must be free of whitespace,
 // into a single Version.
 //
+// The only build metadata it reads is "+buildNNN", as in "1.3.8+build2014".
+// Use NewVersionWithIdentifiers for any other, and for pre-releases in the notation of SemVer 2.0.
+//
 // An io.Reader will give you []byte, hence this (and most functions internally)
 // works on []byte to have as few conversion as possible.
 func NewVersion(str []byte) (Version, error) {
@@ -107,6 +170,8 @@ func NewVersion(str []byte) (Version, error) {
 func (t *Version) Parse(str string) error {
 	t.version = [14]int32{}
 	t.build = 0
+	t.preRelease = ""
+	t.buildMetadata = ""
 
 	return t.unmarshalText([]byte(str))
 }
@@ -148,15 +213,21 @@ func (t *Version) unmarshalText(str []byte) error {
 		case r == '.':
 			idx++
 			column++
-			if column >= 4 || idx >= strlen {
-				return errTooManyColumns
+			if idx >= strlen {
+				return newParseError(errTooManyColumns, str, idx, "a digit")
+			}
+			if column >= 4 {
+				return newParseError(errTooManyColumns, str, idx-1, "at most four columns")
 			}
 			fieldNum++
 			fallthrough
 		case isNumeric(r):
 			idxDelta, n := atoui(str[idx:])
-			if idxDelta == 0 || idxDelta >= 10 { // strlen(maxInt) is 10
-				return errInvalidVersionString
+			if idxDelta == 0 {
+				return newParseError(errInvalidVersionString, str, idx, "a digit")
+			}
+			if idxDelta >= 10 { // strlen(maxInt) is 10
+				return newParseError(errInvalidVersionString, str, idx+9, "at most 9 digits")
 			}
 			t.version[fieldNum] = int32(n)
 
@@ -171,7 +242,7 @@ func (t *Version) unmarshalText(str []byte) error {
 				case fieldNum < idxSpecifierType:
 					fieldNum = idxSpecifierType + 1
 				default:
-					return errInvalidVersionString
+					return newParseError(errInvalidVersionString, str, idx-1, "no further release")
 				}
 				continue
 			}
@@ -182,11 +253,11 @@ func (t *Version) unmarshalText(str []byte) error {
 			}
 
 			if toIdx > strlen {
-				return errInvalidVersionString
+				return newParseError(errInvalidVersionString, str, idx, "a release type or a digit")
 			}
 			typ, known := releaseValue[string(str[idx:toIdx])]
 			if !known {
-				return errInvalidVersionString
+				return newParseError(errInvalidVersionString, str, idx, "alpha, beta, pre, rc, r, or p")
 			}
 			switch {
 			case fieldNum < idxReleaseType:
@@ -194,7 +265,7 @@ func (t *Version) unmarshalText(str []byte) error {
 			case fieldNum < idxSpecifierType:
 				fieldNum = idxSpecifierType
 			default:
-				return errInvalidVersionString
+				return newParseError(errInvalidVersionString, str, idx, "no further release type")
 			}
 			t.version[fieldNum] = int32(typ)
 			if toIdx+1 < strlen && str[toIdx] == '.' {
@@ -205,18 +276,25 @@ func (t *Version) unmarshalText(str []byte) error {
 			column = 0
 			idx = toIdx
 		case r == '+':
-			if strlen < idx+len(buildsuffix)+1 || !bytes.Equal(str[idx:idx+len(buildsuffix)], buildsuffix) {
-				return errInvalidBuildSuffix
+			for i := range buildsuffix {
+				if idx+i >= strlen || str[idx+i] != buildsuffix[i] {
+					return newParseError(errInvalidBuildSuffix, str, idx+i, "+buildNNN")
+				}
 			}
 			idx += len(buildsuffix)
 			idxDelta, n := atoui(str[idx:])
-			if idxDelta > 9 || idx+idxDelta < strlen {
-				return errInvalidBuildSuffix
+			switch {
+			case idxDelta == 0:
+				return newParseError(errInvalidBuildSuffix, str, idx, "a digit")
+			case idxDelta > 9:
+				return newParseError(errInvalidBuildSuffix, str, idx+9, "at most 9 digits")
+			case idx+idxDelta < strlen:
+				return newParseError(errInvalidBuildSuffix, str, idx+idxDelta, "a digit or the end")
 			}
 			t.build = int32(n)
 			return nil
 		default:
-			return errInvalidVersionString
+			return newParseError(errInvalidVersionString, str, idx, "a digit, '.', '-', a release type, or '+build'")
 		}
 	}
 
@@ -242,6 +320,9 @@ func signDelta(a, b [14]int32, cutoffIdx int) int8 {
 //
 // Commutative.
 func (t Version) limitedLess(o Version) bool {
+	if t.preRelease != "" || o.preRelease != "" {
+		return compareWithIdentifiers(&t, &o) < 0
+	}
 	return signDelta(t.version, o.version, idxSpecifierType) < 0
 }
 
@@ -257,6 +338,9 @@ func (t Version) LimitedEqual(o Version) bool {
 	if t.version[idxReleaseType] == common && o.version[idxReleaseType] > common {
 		return t.sharesPrefixWith(o)
 	}
+	if t.preRelease != "" || o.preRelease != "" {
+		return compareWithIdentifiers(&t, &o) == 0
+	}
 	return signDelta(t.version, o.version, idxSpecifierType) == 0
 }
 
@@ -288,6 +372,18 @@ func (t Version) Patch() int {
 	return int(t.version[2])
 }
 
+// Build returns the build metadata of a version, without the leading '+'.
+// It is empty for none.
+func (t Version) Build() string {
+	switch {
+	case t.buildMetadata != "":
+		return t.buildMetadata
+	case t.build != 0:
+		return string(buildsuffix[1:]) + strconv.Itoa(int(t.build))
+	}
+	return ""
+}
+
 // VersionPtrs represents an array with elements derived from~ but smaller than Versions.
 // Use this a proxy for sorting of large collections of Versions,
 // to minimize memory moves.
@@ -8,7 +8,7 @@
 #include "go_asm.h"
 #include "textflag.h"
 
-TEXT ·Compare(SB),NOSPLIT,$0-12
+TEXT ·compareFields(SB),NOSPLIT,$0-12
 	MOVL	a+0(FP), SI
 	MOVL	b+4(FP), DI
 	XORL	CX, CX		// Index of the last examined element.
@@ -8,7 +8,7 @@
 #include "go_asm.h"
 #include "textflag.h"
 
-TEXT ·Compare(SB),NOSPLIT,$0-24
+TEXT ·compareFields(SB),NOSPLIT,$0-24
 	MOVQ	a+0(FP), SI
 	MOVQ	b+8(FP), DI
 	XORQ	CX, CX		// Index of the last examined element.
@@ -14,6 +14,9 @@ package semver
 //
 // The 'build' is not compared.
 func Compare(a, b *Version) int {
+	if a.preRelease != "" || b.preRelease != "" {
+		return compareWithIdentifiers(a, b)
+	}
 	for i := 0; i < len(a.version); i++ {
 		if a.version[i] == b.version[i] {
 			continue
@@ -24,8 +27,16 @@ func Compare(a, b *Version) int {
 	return 0
 }
 
+// compareFields is Compare for Versions without pre-release identifiers.
+func compareFields(a, b *Version) int {
+	return compare(a, b, 0)
+}
+
 // compare works like the exported Compare,
 // only that it allows to skip fields for performance reasons.
+//
+// Like the radix sort variants this considers only the fields,
+// and not any pre-release identifiers.
 func compare(a, b *Version, skipFields uint) int {
 	for i := int(skipFields); i < len(a.version); i++ {
 		if a.version[i] == b.version[i] {
@@ -39,6 +50,12 @@ func compare(a, b *Version, skipFields uint) int {
 
 // Less is a convenience function for sorting.
 func (t *Version) Less(o *Version) bool {
+	if t.preRelease != "" || o.preRelease != "" {
+		if c := compareWithIdentifiers(t, o); c != 0 {
+			return c < 0
+		}
+		return t.build < o.build
+	}
 	for i := 0; i < len(t.version); i++ {
 		if t.version[i] == o.version[i] {
 			continue
@@ -15,8 +15,17 @@ package semver
 //   -1 if a < b
 //
 // The 'build' is not compared.
+func Compare(a, b *Version) int {
+	if a.preRelease != "" || b.preRelease != "" {
+		return compareWithIdentifiers(a, b)
+	}
+	return compareFields(a, b)
+}
+
+// compareFields is Compare for Versions without pre-release identifiers.
+//
 //go:noescape
-func Compare(a, b *Version) int
+func compareFields(a, b *Version) int
 
 // less returns true if t is lexically smaller than o.
 // As side effect, the adjacent 'build' gets compared as well.
@@ -26,6 +35,12 @@ func less(a, b *Version) bool
 
 // Less is a convenience function for sorting.
 func (t *Version) Less(o *Version) bool {
+	if t.preRelease != "" || o.preRelease != "" {
+		if c := compareWithIdentifiers(t, o); c != 0 {
+			return c < 0
+		}
+		return t.build < o.build
+	}
 	return less(t, o)
 }
 
@@ -36,5 +51,5 @@ func (p VersionPtrs) Less(i, j int) bool {
 	} else if p[j] == nil {
 		return true
 	}
-	return less(p[i], p[j])
+	return p[i].Less(p[j])
 }
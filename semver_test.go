@@ -7,6 +7,7 @@ package semver
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"os"
 	"testing"
 
@@ -285,8 +286,12 @@ func TestVersion(t *testing.T) {
 			So(err, ShouldNotBeNil)
 			_, err = NewVersion([]byte("10.0.17763.253+19H3"))
 			So(err, ShouldNotBeNil)
-			e := err.(InvalidStringValue)
+			var e *ParseError
+			So(errors.As(err, &e), ShouldBeTrue)
 			So(e.IsInvalid(), ShouldBeTrue)
+			So(errors.Is(err, errInvalidBuildSuffix), ShouldBeTrue)
+			_, isValue := err.(InvalidStringValue) // As noted in the README.
+			So(isValue, ShouldBeFalse)
 		})
 
 		Convey("with excessive tags", func() {
@@ -314,6 +319,51 @@ func TestVersion(t *testing.T) {
 	})
 }
 
+func TestParseError(t *testing.T) {
+	type expectation struct {
+		offset   int
+		char     rune
+		expected string
+		kind     InvalidStringValue
+	}
+
+	Convey("NewVersion tells where and why it failed with…", t, FailureContinues, func() {
+		for str, exp := range map[string]expectation{
+			"1.2.3.4.5":         {7, '.', "at most four columns", errTooManyColumns},
+			"1.2.":              {4, 0, "a digit", errTooManyColumns},
+			"1..2":              {2, '.', "a digit", errInvalidVersionString},
+			"1.1234567890":      {11, '0', "at most 9 digits", errInvalidVersionString},
+			"1.8-gazilla":       {4, 'g', "alpha, beta, pre, rc, r, or p", errInvalidVersionString},
+			"1.8-alpha-beta-rc": {15, 'r', "no further release type", errInvalidVersionString},
+			"1.8-1-2-3":         {7, '-', "no further release", errInvalidVersionString},
+			"1.8 ":              {3, ' ', "a digit, '.', '-', a release type, or '+build'", errInvalidVersionString},
+			"1.8€":              {3, '€', "a digit, '.', '-', a release type, or '+build'", errInvalidVersionString},
+			"1.8+bu1d2":         {6, '1', "+buildNNN", errInvalidBuildSuffix},
+			"1.8+build":         {9, 0, "a digit", errInvalidBuildSuffix},
+			"1.8+build2x":       {10, 'x', "a digit or the end", errInvalidBuildSuffix},
+		} {
+			Convey(str, func() {
+				_, err := NewVersion([]byte(str))
+				So(errors.Is(err, exp.kind), ShouldBeTrue)
+
+				var e *ParseError
+				So(errors.As(err, &e), ShouldBeTrue)
+				So(e.Input, ShouldEqual, str)
+				So(e.Offset, ShouldEqual, exp.offset)
+				So(e.Char, ShouldEqual, exp.char)
+				So(e.Expected, ShouldEqual, exp.expected)
+			})
+		}
+	})
+
+	Convey("ParseError's message echoes the input", t, func() {
+		_, err := NewVersion([]byte("1.2.x"))
+		So(err.Error(), ShouldEqual, `Given string does not resemble a Version: unexpected 'x' at offset 4 of "1.2.x", expected a digit`)
+		_, err = NewVersion([]byte("1.2."))
+		So(err.Error(), ShouldEqual, `Version consists of too many columns: unexpected end at offset 4 of "1.2.", expected a digit`)
+	})
+}
+
 func TestVersionOrder(t *testing.T) {
 
 	Convey("Version 1.2.3-alpha4 should be…", t, func() {
@@ -5,8 +5,12 @@
 package semver
 
 import (
+	"bytes"
 	"database/sql"
+	"database/sql/driver"
+	"encoding"
 	"encoding/json"
+	"sort"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -17,6 +21,61 @@ var _ string = (Version{}).String()
 
 // var _ driver.Valuer = Version{}
 
+var _ sql.Scanner = &Range{}
+var _ driver.Valuer = Range{}
+var _ encoding.TextMarshaler = Range{}
+var _ encoding.TextUnmarshaler = &Range{}
+
+var _ sql.Scanner = &DebianVersion{}
+var _ driver.Valuer = DebianVersion{}
+var _ encoding.TextMarshaler = DebianVersion{}
+var _ encoding.TextUnmarshaler = &DebianVersion{}
+var _ sort.Interface = DebianVersionPtrs{}
+var _ sql.Scanner = &RPMVersion{}
+var _ driver.Valuer = RPMVersion{}
+var _ encoding.TextMarshaler = RPMVersion{}
+var _ encoding.TextUnmarshaler = &RPMVersion{}
+var _ sort.Interface = RPMVersionPtrs{}
+var _ sql.Scanner = &PEP440Version{}
+var _ driver.Valuer = PEP440Version{}
+var _ encoding.TextMarshaler = PEP440Version{}
+var _ encoding.TextUnmarshaler = &PEP440Version{}
+var _ sort.Interface = PEP440VersionPtrs{}
+var _ sql.Scanner = &MavenVersion{}
+var _ driver.Valuer = MavenVersion{}
+var _ encoding.TextMarshaler = MavenVersion{}
+var _ encoding.TextUnmarshaler = &MavenVersion{}
+var _ sort.Interface = MavenVersionPtrs{}
+var _ sql.Scanner = &ModuleVersion{}
+var _ driver.Valuer = ModuleVersion{}
+var _ encoding.TextMarshaler = ModuleVersion{}
+var _ encoding.TextUnmarshaler = &ModuleVersion{}
+var _ sort.Interface = ModuleVersionPtrs{}
+var _ sql.Scanner = &GemVersion{}
+var _ driver.Valuer = GemVersion{}
+var _ encoding.TextMarshaler = GemVersion{}
+var _ encoding.TextUnmarshaler = &GemVersion{}
+var _ sort.Interface = GemVersionPtrs{}
+var _ sql.Scanner = &NuGetVersion{}
+var _ driver.Valuer = NuGetVersion{}
+var _ encoding.TextMarshaler = NuGetVersion{}
+var _ encoding.TextUnmarshaler = &NuGetVersion{}
+var _ sort.Interface = NuGetVersionPtrs{}
+var _ sql.Scanner = &ApkVersion{}
+var _ driver.Valuer = ApkVersion{}
+var _ encoding.TextMarshaler = ApkVersion{}
+var _ encoding.TextUnmarshaler = &ApkVersion{}
+var _ sort.Interface = ApkVersionPtrs{}
+var _ sql.Scanner = &PacmanVersion{}
+var _ driver.Valuer = PacmanVersion{}
+var _ encoding.TextMarshaler = PacmanVersion{}
+var _ encoding.TextUnmarshaler = &PacmanVersion{}
+var _ sort.Interface = PacmanVersionPtrs{}
+
+var _ Comparator = ComparatorFunc(nil)
+var _ Scheme = scheme{}
+var _ Constraint = constraint{}
+
 func TestSerialization(t *testing.T) {
 	Convey("Versions within JSON…", t, FailureContinues, func() {
 		Convey("get parsed into structs", func() {
@@ -62,7 +121,162 @@ func TestSerialization(t *testing.T) {
 				out, err = json.Marshal(&t)
 				So(err, ShouldBeNil)
 				So(string(out), ShouldEqual, `{"Ver":"`+string(str)+`"}`) // cast to 'string' for legibility
+
+				var back struct{ Ver Version }
+				So(json.Unmarshal(out, &back), ShouldBeNil)
+				So(back.Ver, ShouldResemble, given)
+			}
+		})
+
+		Convey("read back as they were", func() {
+			for _, str := range []string{
+				"1.0.0-rc1", "1.0.0-p1", "1.0.0-rc10",
+				"1.0.0-0", "1.0.0-x.7.z.92", "1.0.0-SNAPSHOT+sha.5114f85",
+			} {
+				given := mustVersion(str)
+				out, err := json.Marshal(given)
+				So(err, ShouldBeNil)
+				var back Version
+				So(json.Unmarshal(out, &back), ShouldBeNil)
+				So(back, ShouldResemble, given)
 			}
 		})
+
+		Convey("are left as they were on errors", func() {
+			given := MustParse("1.2.3")
+			v := given
+			So(v.Scan("garbage"), ShouldNotBeNil)
+			So(v, ShouldResemble, given)
+			So(v.Scan([]byte("1.0.0-gam!ma")), ShouldNotBeNil)
+			So(v, ShouldResemble, given)
+			So(v.Scan(int64(-1)), ShouldNotBeNil)
+			So(v, ShouldResemble, given)
+			So(v.UnmarshalText([]byte("1..2")), ShouldNotBeNil)
+			So(v, ShouldResemble, given)
+			So(json.Unmarshal([]byte(`"1.0.0+"`), &v), ShouldNotBeNil)
+			So(v, ShouldResemble, given)
+		})
+	})
+}
+
+func TestRangeSerialization(t *testing.T) {
+	Convey("Ranges have a canonical form…", t, FailureContinues, func() {
+		for given, expected := range map[string]string{
+			"^1.2":            ">=1.2.0 <2.0.0",
+			"~1.2.3":          ">=1.2.3 <1.3.0",
+			">=1.2.3 <=1.3.0": ">=1.2.3 <=1.3.0",
+			">1.2.3-beta4":    ">1.2.3-beta4",
+			"<=2":             "<3.0.0",
+			">1.2":            ">=1.3.0",
+			"1.2.3":           "1.2.3",
+			"v1.5.3.1":        "1.5.3.1",
+			"1.2 - 2.3":       ">=1.2.0 <2.4.0",
+			"*":               "*",
+			"":                "*",
+		} {
+			Convey(given, func() {
+				r, err := NewRange([]byte(given))
+				So(err, ShouldBeNil)
+				So(r.String(), ShouldEqual, expected)
+
+				Convey("that reads back into an identical Range", func() {
+					back, err := NewRange([]byte(r.String()))
+					So(err, ShouldBeNil)
+					So(back, ShouldResemble, r)
+				})
+			})
+		}
+	})
+
+	Convey("Ranges with pre-release identifiers read back, as from ParseCargoRange", t, func() {
+		for given, expected := range map[string]string{
+			"^1.0.0-SNAPSHOT": ">=1.0.0-SNAPSHOT <2.0.0",
+			"=1.0.0-x.7.z.92": "1.0.0-x.7.z.92",
+		} {
+			r, err := ParseCargoRange(given)
+			So(err, ShouldBeNil)
+			So(r.String(), ShouldEqual, expected)
+
+			back, err := NewRange([]byte(r.String()))
+			So(err, ShouldBeNil)
+			So(back, ShouldResemble, r)
+
+			out, err := json.Marshal(r)
+			So(err, ShouldBeNil)
+			var fromJSON Range
+			So(json.Unmarshal(out, &fromJSON), ShouldBeNil)
+			So(fromJSON, ShouldResemble, r)
+		}
+	})
+
+	Convey("Results of set operations have a canonical form, too", t, func() {
+		set := append(mustRange("^1.2").Complement(), mustRange("1.2.3").Complement()...)
+		for _, r := range append(set, mustRange(">2 <1").Complement()...) {
+			back, err := NewRange([]byte(r.String()))
+			So(err, ShouldBeNil)
+			So(back, ShouldResemble, r)
+		}
+		r, _ := mustRange("1.2.3").Intersect(mustRange("<1.2.3"))
+		back, err := NewRange([]byte(r.String()))
+		So(err, ShouldBeNil)
+		So(back, ShouldResemble, r)
+	})
+
+	Convey("Ranges within JSON…", t, func() {
+		var out struct{ Constraint Range }
+		in := []byte(`{"Constraint": "^1.2"}`)
+
+		err := json.Unmarshal(in, &out)
+		So(err, ShouldBeNil)
+		So(out.Constraint, ShouldResemble, mustRange("^1.2"))
+
+		b, err := json.Marshal(out)
+		So(err, ShouldBeNil)
+		So(string(b), ShouldEqual, `{"Constraint":"\u003e=1.2.0 \u003c2.0.0"}`)
+
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		So(enc.Encode(out), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `{"Constraint":">=1.2.0 <2.0.0"}`+"\n")
+
+		err = json.Unmarshal([]byte(`{"Constraint": "^1.2/3"}`), &out)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Ranges get left alone by JSON null", t, func() {
+		out := struct{ Constraint Range }{mustRange("^1.2")}
+		So(json.Unmarshal([]byte(`{"Constraint": null}`), &out), ShouldBeNil)
+		So(out.Constraint, ShouldResemble, mustRange("^1.2"))
+
+		r := mustRange("~1.2")
+		So(r.UnmarshalJSON([]byte("null")), ShouldBeNil)
+		So(r, ShouldResemble, mustRange("~1.2"))
+	})
+
+	Convey("UnmarshalJSON does not panic on short input", t, func() {
+		var r Range
+		So(func() { _ = r.UnmarshalJSON(nil) }, ShouldNotPanic)
+		So(r.UnmarshalJSON([]byte{}), ShouldBeNil)
+		So(r, ShouldResemble, Range{})
+		So(func() { _ = r.UnmarshalJSON([]byte(`"`)) }, ShouldNotPanic)
+
+		var v Version
+		So(func() { _ = v.UnmarshalJSON(nil) }, ShouldNotPanic)
+		So(v.UnmarshalJSON([]byte("null")), ShouldBeNil)
+		So(v, ShouldResemble, Version{})
+	})
+
+	Convey("Ranges get scanned from databases", t, func() {
+		var r Range
+		So(r.Scan("~1.2"), ShouldBeNil)
+		So(r, ShouldResemble, mustRange("~1.2"))
+		So(r.Scan([]byte("<3")), ShouldBeNil)
+		So(r, ShouldResemble, mustRange("<3"))
+		So(r.Scan(int64(1)), ShouldEqual, errInvalidRangeType)
+
+		v, err := r.Value()
+		So(err, ShouldBeNil)
+		So(v, ShouldEqual, "<3.0.0")
 	})
 }
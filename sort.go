@@ -53,19 +53,43 @@ func (p VersionPtrs) Sort() {
 		tmp[i] = nil
 	}
 	versionPointerBuffer.Put(buf)
+	p.sortIdentifiedPreReleases()
+}
+
+// sortIdentifiedPreReleases reorders pre-releases that share a prefix,
+// if any has identifiers beyond the fixed vocabulary.
+// Radix sort considers only the fields, and will have put them first.
+func (p VersionPtrs) sortIdentifiedPreReleases() {
+	startIdx, hasIdentifiers := 0, false
+	for i, v := range p {
+		if v == nil {
+			p = p[:i]
+			break
+		}
+		if !v.sharesPrefixWith(*p[startIdx]) {
+			if hasIdentifiers {
+				sort.Sort(p[startIdx:i])
+			}
+			startIdx, hasIdentifiers = i, false
+		}
+		hasIdentifiers = hasIdentifiers || v.preRelease != ""
+	}
+	if hasIdentifiers {
+		sort.Sort(p[startIdx:])
+	}
 }
 
 // multikeyRadixSort exploits the typical distribution of Version values
 // to use  two keys at once  in a radix-sort run.
 func (p VersionPtrs) multikeyRadixSort(tmp []*Version, keyIndex uint8) {
 	// Some fields can be negative and need to get a bump. (Mind order in memory!)
-	// As "alpha" is the lowest one, use its absolute value.
+	// As "identified" is the lowest one, use its absolute value.
 	var fieldAdjustment uint64 = 0
 	switch keyIndex {
 	case 3, 8:
-		fieldAdjustment = (-alpha) << 32
+		fieldAdjustment = (-identified) << 32
 	case 4, 9:
-		fieldAdjustment = (-alpha)
+		fieldAdjustment = (-identified)
 	}
 
 	// Collate the histogram.
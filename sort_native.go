@@ -15,6 +15,7 @@ package semver
 func twoFieldKey(v *[14]int32, fieldAdjustment uint64, keyIndex uint8) uint
 
 // isSorted is called by radixSort and multikeyRadixSort, and won't contain any nil.
+// Like those it considers only the fields, and not any pre-release identifiers.
 func (p VersionPtrs) isSorted(skipFields uint) bool {
 	if len(p) < 2 {
 		return true
@@ -22,7 +23,7 @@ func (p VersionPtrs) isSorted(skipFields uint) bool {
 
 	previous := p[0]
 	for _, ptr := range p {
-		if Compare(previous, ptr) > 0 {
+		if compareFields(previous, ptr) > 0 {
 			return false
 		}
 		previous = ptr
@@ -0,0 +1,89 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"bytes"
+)
+
+// Errors that are thrown by the strict parser, one per violated rule of SemVer 2.0.0.
+const (
+	errStrictPrefix       InvalidStringValue = "SemVer 2.0.0: Version must start with a digit, without a prefix such as 'v'"
+	errStrictColumns      InvalidStringValue = "SemVer 2.0.0: Version must consist of MAJOR.MINOR.PATCH, which are numbers"
+	errStrictFourthColumn InvalidStringValue = "SemVer 2.0.0: Version must not have a fourth column"
+	errStrictLeadingZero  InvalidStringValue = "SemVer 2.0.0: Numbers must not have leading zeroes"
+	errStrictPreRelease   InvalidStringValue = "SemVer 2.0.0: Pre-release must be dot-separated identifiers of [0-9A-Za-z-]"
+	errStrictBuild        InvalidStringValue = "SemVer 2.0.0: Build metadata must be dot-separated identifiers of [0-9A-Za-z-]"
+)
+
+// NewVersionStrict translates the given string into a Version
+// only if it is exactly in SemVer 2.0.0 notation.
+//
+// Unlike NewVersion this rejects a leading 'v', missing or a fourth column,
+// leading zeroes, and separators other than '.', '-' and '+'.
+// The returned error names the violated rule.
+//
+// Any pre-release is kept verbatim and is ordered by §11 SemVer,
+// even if it uses a release type such as "rc" or "p".
+func NewVersionStrict(str []byte) (Version, error) {
+	if len(str) == 0 || !isNumeric(str[0]) {
+		return Version{}, errStrictPrefix
+	}
+
+	var core, preRelease, metadata []byte = str, nil, nil
+	if idx := bytes.IndexByte(core, '+'); idx >= 0 {
+		core, metadata = core[:idx], core[idx+1:]
+		if !isValidIdentifiers(metadata) {
+			return Version{}, errStrictBuild
+		}
+	}
+	if idx := bytes.IndexByte(core, '-'); idx >= 0 {
+		core, preRelease = core[:idx], core[idx+1:]
+		if !isValidIdentifiers(preRelease) {
+			return Version{}, errStrictPreRelease
+		}
+		for _, identifier := range bytes.Split(preRelease, []byte{'.'}) {
+			if len(identifier) > 1 && identifier[0] == '0' && isNumericIdentifier(identifier) {
+				return Version{}, errStrictLeadingZero
+			}
+		}
+	}
+
+	columns := bytes.Split(core, []byte{'.'})
+	switch {
+	case len(columns) == 4:
+		return Version{}, errStrictFourthColumn
+	case len(columns) != 3:
+		return Version{}, errStrictColumns
+	}
+	for _, column := range columns {
+		if len(column) == 0 || !isNumericIdentifier(column) {
+			return Version{}, errStrictColumns
+		}
+		if len(column) > 1 && column[0] == '0' {
+			return Version{}, errStrictLeadingZero
+		}
+	}
+
+	ver, err := NewVersion(core)
+	if err != nil {
+		return ver, err
+	}
+	if len(preRelease) > 0 {
+		ver.version[idxReleaseType] = identified
+		ver.preRelease = string(preRelease)
+	}
+	if len(metadata) > 0 {
+		ver.setBuildMetadata(metadata)
+	}
+	return ver, nil
+}
+
+// ParseStrict is NewVersionStrict for strings.
+//
+// Use this to validate tags that must be exactly in SemVer 2.0.0 notation.
+func ParseStrict(str string) (Version, error) {
+	return NewVersionStrict([]byte(str))
+}
@@ -0,0 +1,71 @@
+// Copyright 2014 The Semver Package Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewVersionStrict(t *testing.T) {
+	Convey("NewVersionStrict accepts SemVer 2.0.0…", t, FailureContinues, func() {
+		for _, str := range []string{
+			"0.0.0", "1.2.3", "10.20.30", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-0.3.7",
+			"1.0.0-x.7.z.92", "1.0.0-x-y-z.--", "1.0.0+20130313144700",
+			"1.0.0-beta+exp.sha.5114f85", "1.0.0+21AF26D3----117B344092BD",
+			"2.0.0-rc.1+build.123", "1.2.3+build5",
+		} {
+			Convey(str, func() {
+				v, err := ParseStrict(str)
+				So(err, ShouldBeNil)
+				So(v.String(), ShouldEqual, str)
+			})
+		}
+	})
+
+	Convey("NewVersionStrict rejects, naming the rule…", t, FailureContinues, func() {
+		for str, expected := range map[string]error{
+			"v1.2.3":             errStrictPrefix,
+			"":                   errStrictPrefix,
+			"1.2":                errStrictColumns,
+			"1":                  errStrictColumns,
+			"1.2.3.4":            errStrictFourthColumn,
+			"1..3":               errStrictColumns,
+			"1.2.3_alpha":        errStrictColumns,
+			"1.2.3a":             errStrictColumns,
+			"01.2.3":             errStrictLeadingZero,
+			"1.02.3":             errStrictLeadingZero,
+			"1.2.3-01":           errStrictLeadingZero,
+			"1.2.3-":             errStrictPreRelease,
+			"1.2.3-alpha..1":     errStrictPreRelease,
+			"1.2.3-alpha_1":      errStrictPreRelease,
+			"1.2.3+":             errStrictBuild,
+			"1.2.3+build!":       errStrictBuild,
+			"1.2.3-rc.1+x..y":    errStrictBuild,
+			"99999999999.2.3":    errInvalidVersionString,
+			"1.2.3-alpha.01+sha": errStrictLeadingZero,
+		} {
+			Convey(str, func() {
+				_, err := ParseStrict(str)
+				So(errors.Is(err, expected), ShouldBeTrue)
+			})
+		}
+	})
+
+	Convey("Pre-releases are ordered by SemVer 2.0.0", t, func() {
+		ordered := []string{
+			"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+			"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-p.1", "1.0.0-rc.1", "1.0.0",
+		}
+		for i := 0; i+1 < len(ordered); i++ {
+			a, _ := ParseStrict(ordered[i])
+			b, _ := ParseStrict(ordered[i+1])
+			So(Compare(&a, &b), ShouldEqual, -1)
+			So(a.IsAPreRelease(), ShouldBeTrue)
+		}
+	})
+}